@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package bridge
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type bridgeClient struct {
+	bridge *bridge
+}
+
+// NewClient - returns a new networkservice.NetworkServiceClient that attaches the forwarder side interface of the
+// Ethernet payload connection stored with peer.Store to the Linux bridge with the given name on Request. The bridge
+// is created if missing. The interface becomes untagged member of the EthernetContext.VlanTag VLAN, the static FDB
+// entry is added for the EthernetContext.DstMac. The interface is detached on Close, the bridge created by the
+// elements with the same name is deleted when no interfaces are left. The created bridge is marked with the alias, so
+// the one created before the restart is deleted as well.
+func NewClient(name string) networkservice.NetworkServiceClient {
+	return &bridgeClient{
+		bridge: getBridge(name),
+	}
+}
+
+func (c *bridgeClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.bridge.attach(ctx, conn, metadata.IsClient(c)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *bridgeClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := c.bridge.detach(ctx, metadata.IsClient(c)); err != nil {
+		log.FromContext(ctx).Errorf("bridgeClient detach: %v", err.Error())
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
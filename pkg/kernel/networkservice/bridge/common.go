@@ -0,0 +1,350 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package bridge
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/payload"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/peer"
+)
+
+const (
+	// defaultVID is the VLAN the kernel adds to the bridge ports with VLAN filtering
+	defaultVID = 1
+	// createdAlias marks the bridge created by the elements, so it is deleted even if it is created before the restart
+	createdAlias = "nsm-bridge"
+)
+
+type stateKey struct{}
+
+// state is the bridge port configuration of the connection stored in the metadata
+type state struct {
+	portName string
+	vid      uint16
+	mac      net.HardwareAddr
+}
+
+// bridges are shared by the client and server elements with the same bridge name
+var bridges genericsync.Map[string, *bridge]
+
+// bridge is the named Linux bridge shared by the connections
+type bridge struct {
+	name string
+	// Protecting the bridge creation and deletion
+	mu      sync.Mutex
+	created bool
+}
+
+func getBridge(name string) *bridge {
+	b, _ := bridges.LoadOrStore(name, &bridge{name: name})
+	return b
+}
+
+// attach enslaves the forwarder side interface of the Ethernet connection stored with peer.Store to the bridge
+func (b *bridge) attach(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	if conn.GetPayload() != payload.Ethernet {
+		return nil
+	}
+	peerLink, ok := peer.Load(ctx, isClient)
+	if !ok {
+		return errors.Errorf("bridge: no forwarder side interface found for the connection %s", conn.GetId())
+	}
+
+	ethernetContext := conn.GetContext().GetEthernetContext()
+	s := &state{
+		portName: peerLink.Attrs().Name,
+		vid:      uint16(ethernetContext.GetVlanTag()),
+	}
+	// The server side MAC is behind the client side port and vice versa
+	macStr := ethernetContext.GetSrcMac()
+	if isClient {
+		macStr = ethernetContext.GetDstMac()
+	}
+	if macStr != "" {
+		mac, err := net.ParseMAC(macStr)
+		if err != nil {
+			return errors.Wrapf(err, "bridge: invalid MAC address: %v", macStr)
+		}
+		s.mac = mac
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var prev *state
+	if rawState, ok := metadata.Map(ctx, isClient).Load(stateKey{}); ok {
+		prev = rawState.(*state)
+	}
+	metadata.Map(ctx, isClient).Store(stateKey{}, s)
+
+	br, err := b.ensure(ctx, s.vid != 0)
+	if err != nil {
+		return err
+	}
+
+	// The stored link attributes may be outdated
+	port, err := netlink.LinkByName(s.portName)
+	if err != nil {
+		return errors.Wrapf(err, "bridge: failed to find link %s", s.portName)
+	}
+	if port.Attrs().MasterIndex != br.Attrs().Index {
+		now := time.Now()
+		if err = netlink.LinkSetMasterByIndex(port, br.Attrs().Index); err != nil {
+			return errors.Wrapf(err, "bridge: failed to attach %s to bridge %s", s.portName, b.name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", s.portName).
+			WithField("bridge", b.name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetMasterByIndex").Debug("completed")
+	}
+
+	if prev != nil && prev.portName == s.portName {
+		if err = cleanupPrevious(ctx, port, prev, s); err != nil {
+			return err
+		}
+	}
+	if err = setVLAN(ctx, port, s.vid); err != nil {
+		return err
+	}
+	if s.mac != nil {
+		return fdbSet(ctx, port, s.mac, s.vid)
+	}
+	return nil
+}
+
+// ensure returns the bridge, it is created if missing. VLAN filtering is enabled if required.
+func (b *bridge) ensure(ctx context.Context, vlanFiltering bool) (netlink.Link, error) {
+	br, err := netlink.LinkByName(b.name)
+	if err != nil {
+		// VLAN filtering is not set if not required, the kernel may be built without it
+		bridge := &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: b.name}}
+		if vlanFiltering {
+			bridge.VlanFiltering = &vlanFiltering
+		}
+		br = bridge
+
+		now := time.Now()
+		if err = netlink.LinkAdd(br); err != nil {
+			return nil, errors.Wrapf(err, "bridge: failed to create bridge %s", b.name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", b.name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkAdd").Debug("completed")
+
+		if br, err = netlink.LinkByName(b.name); err != nil {
+			return nil, errors.Wrapf(err, "bridge: failed to find bridge %s", b.name)
+		}
+
+		// The alias is not set by the kernel on the link creation
+		now = time.Now()
+		if err = netlink.LinkSetAlias(br, createdAlias); err != nil {
+			return nil, errors.Wrapf(err, "bridge: failed to set alias %s on bridge %s", createdAlias, b.name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", b.name).
+			WithField("link.Alias", createdAlias).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetAlias").Debug("completed")
+		b.created = true
+	} else if br.Attrs().Alias == createdAlias {
+		// The bridge has been created before the restart
+		b.created = true
+	}
+
+	if existing, ok := br.(*netlink.Bridge); !ok {
+		return nil, errors.Errorf("bridge: link %s already exists and it is not bridge", b.name)
+	} else if vlanFiltering && (existing.VlanFiltering == nil || !*existing.VlanFiltering) {
+		if err = netlink.BridgeSetVlanFiltering(br, true); err != nil {
+			return nil, errors.Wrapf(err, "bridge: failed to enable VLAN filtering on %s", b.name)
+		}
+	}
+
+	if err = netlink.LinkSetUp(br); err != nil {
+		return nil, errors.Wrapf(err, "bridge: failed to setup link for the bridge %s", b.name)
+	}
+	return br, nil
+}
+
+// setVLAN makes the port untagged member of the VLAN, the frames received on the port are assigned to it
+func setVLAN(ctx context.Context, port netlink.Link, vid uint16) error {
+	if vid == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if err := netlink.BridgeVlanAdd(port, vid, true, true, false, true); err != nil {
+		return errors.Wrapf(err, "bridge: failed to add VLAN %d to %s", vid, port.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", port.Attrs().Name).
+		WithField("vid", vid).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "BridgeVlanAdd").Debug("completed")
+
+	// The port should not be a member of the default VLAN
+	if vid != defaultVID {
+		if err := netlink.BridgeVlanDel(port, defaultVID, false, false, false, true); err != nil && !errors.Is(err, unix.ENOENT) {
+			return errors.Wrapf(err, "bridge: failed to delete default VLAN from %s", port.Attrs().Name)
+		}
+	}
+	return nil
+}
+
+// cleanupPrevious removes the VLAN and the FDB entry set for the connection before the refresh if they have been
+// changed
+func cleanupPrevious(ctx context.Context, port netlink.Link, prev, s *state) error {
+	if prev.mac != nil && (prev.mac.String() != s.mac.String() || prev.vid != s.vid) {
+		if err := fdbDel(ctx, port, prev.mac, prev.vid); err != nil {
+			return err
+		}
+	}
+	if prev.vid != 0 && prev.vid != s.vid {
+		if err := netlink.BridgeVlanDel(port, prev.vid, false, false, false, true); err != nil && !errors.Is(err, unix.ENOENT) {
+			return errors.Wrapf(err, "bridge: failed to delete VLAN %d from %s", prev.vid, port.Attrs().Name)
+		}
+	}
+	// The port without VLAN returns to the default one deleted by setVLAN
+	if prev.vid != 0 && s.vid == 0 {
+		now := time.Now()
+		if err := netlink.BridgeVlanAdd(port, defaultVID, true, true, false, true); err != nil {
+			return errors.Wrapf(err, "bridge: failed to add default VLAN to %s", port.Attrs().Name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", port.Attrs().Name).
+			WithField("vid", defaultVID).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "BridgeVlanAdd").Debug("completed")
+	}
+	return nil
+}
+
+func newFDBEntry(port netlink.Link, mac net.HardwareAddr, vid uint16) *netlink.Neigh {
+	return &netlink.Neigh{
+		LinkIndex:    port.Attrs().Index,
+		Family:       unix.AF_BRIDGE,
+		State:        netlink.NUD_NOARP,
+		Flags:        netlink.NTF_MASTER,
+		HardwareAddr: mac,
+		Vlan:         int(vid),
+	}
+}
+
+func fdbSet(ctx context.Context, port netlink.Link, mac net.HardwareAddr, vid uint16) error {
+	now := time.Now()
+	if err := netlink.NeighSet(newFDBEntry(port, mac, vid)); err != nil {
+		return errors.Wrapf(err, "bridge: failed to add static FDB entry %s to %s", mac, port.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", port.Attrs().Name).
+		WithField("hardwareAddr", mac).
+		WithField("vid", vid).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "NeighSet").Debug("completed")
+	return nil
+}
+
+func fdbDel(ctx context.Context, port netlink.Link, mac net.HardwareAddr, vid uint16) error {
+	now := time.Now()
+	if err := netlink.NeighDel(newFDBEntry(port, mac, vid)); err != nil && !errors.Is(err, unix.ENOENT) {
+		return errors.Wrapf(err, "bridge: failed to delete static FDB entry %s from %s", mac, port.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", port.Attrs().Name).
+		WithField("hardwareAddr", mac).
+		WithField("vid", vid).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "NeighDel").Debug("completed")
+	return nil
+}
+
+// detach releases the port from the bridge, the kernel flushes the port VLANs and FDB entries. The bridge created
+// by the element is deleted when no ports are left.
+func (b *bridge) detach(ctx context.Context, isClient bool) error {
+	rawState, ok := metadata.Map(ctx, isClient).LoadAndDelete(stateKey{})
+	if !ok {
+		return nil
+	}
+	s := rawState.(*state)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// The port is deleted together with the connection interface
+	if port, err := netlink.LinkByName(s.portName); err == nil && port.Attrs().MasterIndex != 0 {
+		now := time.Now()
+		if err := netlink.LinkSetNoMaster(port); err != nil {
+			return errors.Wrapf(err, "bridge: failed to detach %s from bridge %s", s.portName, b.name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", s.portName).
+			WithField("bridge", b.name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetNoMaster").Debug("completed")
+	}
+
+	return b.deleteIfUnused(ctx)
+}
+
+func (b *bridge) deleteIfUnused(ctx context.Context) error {
+	if !b.created {
+		return nil
+	}
+	br, err := netlink.LinkByName(b.name)
+	if err != nil {
+		b.created = false
+		return nil
+	}
+
+	links, err := netlink.LinkList()
+	if err != nil {
+		return errors.Wrap(err, "bridge: failed to list links")
+	}
+	for _, l := range links {
+		if l.Attrs().MasterIndex == br.Attrs().Index {
+			return nil
+		}
+	}
+
+	now := time.Now()
+	if err := netlink.LinkDel(br); err != nil {
+		return errors.Wrapf(err, "bridge: failed to delete bridge %s", b.name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", b.name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	b.created = false
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package bridge contains chain elements that attach the forwarder side interfaces of the Ethernet payload connections
+// to the named Linux bridge with the VLAN filtering and the static FDB entries from the connection Ethernet context
+package bridge
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type bridgeServer struct {
+	bridge *bridge
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that attaches the forwarder side interface of the
+// Ethernet payload connection stored with peer.Store, e.g. by veth.NewServer, to the Linux bridge with the given name
+// on Request. The bridge is created if missing. The interface becomes untagged member of the
+// EthernetContext.VlanTag VLAN, the static FDB entry is added for the EthernetContext.SrcMac. The interface is
+// detached on Close, the bridge created by the elements with the same name is deleted when no interfaces are left.
+// The created bridge is marked with the alias, so the one created before the restart is deleted as well.
+func NewServer(name string) networkservice.NetworkServiceServer {
+	return &bridgeServer{
+		bridge: getBridge(name),
+	}
+}
+
+func (s *bridgeServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.bridge.attach(ctx, conn, metadata.IsClient(s)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *bridgeServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := s.bridge.detach(ctx, metadata.IsClient(s)); err != nil {
+		log.FromContext(ctx).Errorf("bridgeServer detach: %v", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2020-2023 Cisco and/or its affiliates.
+// Copyright (c) 2020-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2021-2023 Nordix Foundation.
 //
@@ -25,6 +25,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/iptables4nattemplate"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/iptables6nattemplate"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/mtu"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/routelocalnet"
 
@@ -32,8 +33,10 @@ import (
 
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/ipcontext/ipaddress"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/ipcontext/ipneighbors"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/ipcontext/iprule"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/ipcontext/routes"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/pinggrouprange"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/vlan"
 )
 
 // NewClient provides a NetworkServiceClient that applies the connectioncontext to a kernel interface
@@ -63,10 +66,13 @@ func NewClient() networkservice.NetworkServiceClient {
 	return chain.NewNetworkServiceClient(
 		mtu.NewClient(),
 		ipneighbors.NewClient(),
+		iprule.NewClient(),
 		routes.NewClient(),
 		ipaddress.NewClient(),
 		routelocalnet.NewClient(),
 		iptables4nattemplate.NewClient(),
+		iptables6nattemplate.NewClient(),
 		pinggrouprange.NewClient(),
+		vlan.NewClient(),
 	)
 }
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package encap
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/kernelroute"
+)
+
+// kernelRoutes are the encapsulated routes installed for the connection keyed by the route destination
+type kernelRoutes map[string]*netlink.Route
+
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, installed *genericsync.Map[string, kernelRoutes]) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	encap, err := getEncap(conn)
+	if err != nil {
+		return err
+	}
+
+	current, ok := installed.Load(conn.GetId())
+	if !ok {
+		if encap == nil {
+			return nil
+		}
+		current = make(kernelRoutes)
+		installed.Store(conn.GetId(), current)
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	ifName := link.GetInterfaceName(mechanism)
+	l, err := netlinkHandle.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "encap: failed to find link %s", ifName)
+	}
+
+	routes := conn.GetContext().GetIpContext().GetSrcRoutesWithExplicitNextHop()
+	if isClient {
+		routes = conn.GetContext().GetIpContext().GetDstRoutesWithExplicitNextHop()
+	}
+
+	toAdd := make(kernelRoutes)
+	if encap != nil {
+		for _, route := range routes {
+			kernelRoute, err := toKernelRoute(l, route, encap)
+			if err != nil {
+				return err
+			}
+			toAdd[kernelRoute.Dst.String()] = kernelRoute
+		}
+	}
+
+	// Remove no longer existing routes and the ones with the changed encapsulation
+	for key, kernelRoute := range current {
+		if newRoute, ok := toAdd[key]; ok && newRoute.Encap.Equal(kernelRoute.Encap) {
+			continue
+		}
+		if err := routeDel(ctx, netlinkHandle, ifName, kernelRoute); err != nil {
+			return err
+		}
+		delete(current, key)
+	}
+
+	// Add new routes and replace the existing ones
+	for key, kernelRoute := range toAdd {
+		if err := kernelroute.Replace(ctx, netlinkHandle, ifName, kernelRoute); err != nil {
+			return err
+		}
+		current[key] = kernelRoute
+	}
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, installed *genericsync.Map[string, kernelRoutes]) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	current, ok := installed.LoadAndDelete(conn.GetId())
+	if !ok {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	for _, kernelRoute := range current {
+		if err := routeDel(ctx, netlinkHandle, link.GetInterfaceName(mechanism), kernelRoute); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toKernelRoute(l netlink.Link, route *networkservice.Route, encap netlink.Encap) (*netlink.Route, error) {
+	kernelRoute, err := kernelroute.FromRoute(l, 0, netlink.SCOPE_UNIVERSE, route)
+	if err != nil {
+		return nil, err
+	}
+	if encap.Type() == nl.LWTUNNEL_ENCAP_SEG6 && encap.(*netlink.SEG6Encap).Mode == nl.SEG6_IPTUN_MODE_INLINE && kernelRoute.Dst.IP.To4() != nil {
+		return nil, errors.Errorf("encap: %s mode is not supported for IPv4 route %s", SEG6ModeInline, kernelRoute.Dst)
+	}
+	kernelRoute.Encap = encap
+	return kernelRoute, nil
+}
+
+func routeDel(ctx context.Context, handle *netlink.Handle, linkName string, kernelRoute *netlink.Route) error {
+	family := netlink.FAMILY_V6
+	if kernelRoute.Dst.IP.To4() != nil {
+		family = netlink.FAMILY_V4
+	}
+
+	// The route may be already deleted by the kernel together with the interface or replaced by the plain one, so
+	// we delete it only if it is still encapsulated
+	routes, err := handle.RouteListFiltered(family, &netlink.Route{
+		Dst:   kernelRoute.Dst,
+		Table: unix.RT_TABLE_MAIN,
+	}, netlink.RT_FILTER_DST|netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return errors.Wrapf(err, "encap: failed to list routes to %s", kernelRoute.Dst)
+	}
+
+	for i := range routes {
+		if routes[i].Encap == nil || !routes[i].Encap.Equal(kernelRoute.Encap) {
+			continue
+		}
+		if err := kernelroute.Del(ctx, handle, linkName, &routes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package encap provides networkservice chain elements that attach the lightweight tunnel encapsulation (MPLS label
+// stack or SRv6 segment list) to the routes from the connection context. The encapsulation is requested by the
+// connection labels or the mechanism parameters, see TypeKey. IP-in-IP encapsulation is not supported: the kernel
+// applies it only to the routes over a collect_md tunnel device, not over the connection interface.
+package encap
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package encap
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Label/mechanism parameter keys of the encapsulation, mechanism parameters override labels
+const (
+	// TypeKey - encapsulation type: TypeMPLS or TypeSEG6
+	TypeKey = "encap.type"
+	// MPLSLabelsKey - MPLS label stack, the outermost label first: "100/200"
+	MPLSLabelsKey = "encap.mpls.labels"
+	// SEG6SegmentsKey - SRv6 segment list, the first segment first: "fc00::1,fc00::2"
+	SEG6SegmentsKey = "encap.seg6.segments"
+	// SEG6ModeKey - SRv6 mode: SEG6ModeEncap (default) or SEG6ModeInline
+	SEG6ModeKey = "encap.seg6.mode"
+)
+
+// Encapsulation types and modes
+const (
+	TypeMPLS = "mpls"
+	TypeSEG6 = "seg6"
+
+	SEG6ModeEncap  = "encap"
+	SEG6ModeInline = "inline"
+)
+
+var keys = []string{TypeKey, MPLSLabelsKey, SEG6SegmentsKey, SEG6ModeKey}
+
+// getEncap returns the encapsulation requested for the connection or nil
+func getEncap(conn *networkservice.Connection) (netlink.Encap, error) {
+	values := make(map[string]string)
+	for _, source := range []map[string]string{conn.GetLabels(), conn.GetMechanism().GetParameters()} {
+		for _, key := range keys {
+			if value, ok := source[key]; ok {
+				values[key] = value
+			}
+		}
+	}
+
+	switch encapType := values[TypeKey]; encapType {
+	case "":
+		return nil, nil
+	case TypeMPLS:
+		return parseMPLS(values[MPLSLabelsKey])
+	case TypeSEG6:
+		return parseSEG6(values[SEG6SegmentsKey], values[SEG6ModeKey])
+	default:
+		return nil, errors.Errorf("encap: unsupported %s: %s", TypeKey, encapType)
+	}
+}
+
+func parseMPLS(value string) (netlink.Encap, error) {
+	encap := &netlink.MPLSEncap{}
+	for _, s := range strings.Split(value, "/") {
+		label, err := strconv.ParseUint(strings.TrimSpace(s), 0, 20)
+		if err != nil {
+			return nil, errors.Wrapf(err, "encap: invalid %s: %s", MPLSLabelsKey, value)
+		}
+		encap.Labels = append(encap.Labels, int(label))
+	}
+	return encap, nil
+}
+
+func parseSEG6(value, mode string) (netlink.Encap, error) {
+	encap := &netlink.SEG6Encap{}
+	switch mode {
+	case "", SEG6ModeEncap:
+		encap.Mode = nl.SEG6_IPTUN_MODE_ENCAP
+	case SEG6ModeInline:
+		encap.Mode = nl.SEG6_IPTUN_MODE_INLINE
+	default:
+		return nil, errors.Errorf("encap: unsupported %s: %s", SEG6ModeKey, mode)
+	}
+
+	for _, s := range strings.Split(value, ",") {
+		segment := net.ParseIP(strings.TrimSpace(s))
+		if segment == nil || segment.To4() != nil {
+			return nil, errors.Errorf("encap: invalid %s: %s", SEG6SegmentsKey, value)
+		}
+		encap.Segments = append(encap.Segments, segment)
+	}
+
+	// Segment Routing Header keeps the segments in the reverse order
+	for i, j := 0, len(encap.Segments)-1; i < j; i, j = i+1, j-1 {
+		encap.Segments[i], encap.Segments[j] = encap.Segments[j], encap.Segments[i]
+	}
+	return encap, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package encap
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+)
+
+func TestParseMPLS(t *testing.T) {
+	samples := []struct {
+		name   string
+		value  string
+		labels []int
+	}{
+		{name: "Single", value: "100", labels: []int{100}},
+		{name: "Stack", value: "100/200", labels: []int{100, 200}},
+		{name: "Spaces", value: " 100 / 200 ", labels: []int{100, 200}},
+		{name: "Hex", value: "0x10", labels: []int{16}},
+		{name: "MaxLabel", value: "1048575", labels: []int{1048575}},
+		{name: "Empty", value: ""},
+		{name: "EmptyLabel", value: "100//200"},
+		{name: "NotNumber", value: "label"},
+		{name: "Negative", value: "-1"},
+		{name: "TooLarge", value: "1048576"},
+	}
+
+	for _, sample := range samples {
+		t.Run(sample.name, func(t *testing.T) {
+			encap, err := parseMPLS(sample.value)
+			if sample.labels == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, &netlink.MPLSEncap{Labels: sample.labels}, encap)
+		})
+	}
+}
+
+func TestParseSEG6(t *testing.T) {
+	samples := []struct {
+		name     string
+		value    string
+		mode     string
+		segments []string
+		seg6Mode int
+	}{
+		{name: "Single", value: "fc00::1", segments: []string{"fc00::1"}, seg6Mode: nl.SEG6_IPTUN_MODE_ENCAP},
+		{
+			name:     "Reversed",
+			value:    "fc00::1, fc00::2",
+			mode:     SEG6ModeEncap,
+			segments: []string{"fc00::2", "fc00::1"},
+			seg6Mode: nl.SEG6_IPTUN_MODE_ENCAP,
+		},
+		{name: "Inline", value: "fc00::1", mode: SEG6ModeInline, segments: []string{"fc00::1"}, seg6Mode: nl.SEG6_IPTUN_MODE_INLINE},
+		{name: "Empty", value: ""},
+		{name: "IPv4", value: "10.0.0.1"},
+		{name: "NotIP", value: "fc00::1,segment"},
+		{name: "UnsupportedMode", value: "fc00::1", mode: "l2encap"},
+	}
+
+	for _, sample := range samples {
+		t.Run(sample.name, func(t *testing.T) {
+			encap, err := parseSEG6(sample.value, sample.mode)
+			if sample.segments == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			expected := &netlink.SEG6Encap{Mode: sample.seg6Mode}
+			for _, segment := range sample.segments {
+				expected.Segments = append(expected.Segments, net.ParseIP(segment))
+			}
+			require.Equal(t, expected, encap)
+		})
+	}
+}
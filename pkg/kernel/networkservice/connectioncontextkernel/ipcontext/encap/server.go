@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package encap
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type encapServer struct {
+	routes *genericsync.Map[string, kernelRoutes]
+}
+
+// NewServer creates a NetworkServiceServer that replaces the routes from the connection context with the routes
+// encapsulated into MPLS or SRv6 over the kernel interface in the client network namespace iff the selected mechanism
+// for the connection is a kernel mechanism and the encapsulation is requested by the connection labels or the
+// mechanism parameters. It should be placed before routes.NewServer in the chain, so it is applied after it.
+func NewServer() networkservice.NetworkServiceServer {
+	return &encapServer{
+		routes: new(genericsync.Map[string, kernelRoutes]),
+	}
+}
+
+func (e *encapServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create(ctx, conn, metadata.IsClient(e), e.routes); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := e.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (e *encapServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := del(ctx, conn, e.routes); err != nil {
+		log.FromContext(ctx).Errorf("encapServer del: %v", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2020-2022 Cisco and/or its affiliates.
+// Copyright (c) 2020-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
@@ -33,6 +33,7 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 )
 
@@ -88,5 +89,8 @@ func (i *ipaddressClient) Request(ctx context.Context, request *networkservice.N
 }
 
 func (i *ipaddressClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := restoreDisableIPv6(ctx, conn, metadata.IsClient(i)); err != nil {
+		log.FromContext(ctx).Errorf("ipaddressClient restoreDisableIPv6: %v", err.Error())
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
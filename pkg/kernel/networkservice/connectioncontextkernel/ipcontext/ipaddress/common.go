@@ -1,6 +1,6 @@
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
-// Copyright (c) 2020-2024 Cisco and/or its affiliates.
+// Copyright (c) 2020-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -26,6 +26,8 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
@@ -37,11 +39,12 @@ import (
 	"golang.org/x/sys/unix"
 
 	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/linkstate"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
 )
 
 func create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		// Note: These are switched from normal because if we are the client, we need to assign the IP
 		// in the Endpoints NetNS for the Dst.  If we are the *server* we need to assign the IP for the
 		// clients NetNS (ie the source).
@@ -59,7 +62,7 @@ func create(ctx context.Context, conn *networkservice.Connection, isClient bool)
 		}
 		defer netlinkHandle.Close()
 
-		ifName := mechanism.GetInterfaceName()
+		ifName := link.GetInterfaceName(mechanism)
 		l, err := netlinkHandle.LinkByName(ifName)
 		if err != nil {
 			return errors.Wrapf(err, "failed to find link %s", ifName)
@@ -83,8 +86,13 @@ func create(ctx context.Context, conn *networkservice.Connection, isClient bool)
 		}
 		defer func() { _ = targetNetNS.Close() }()
 
-		disableIPv6Filename := fmt.Sprintf("/proc/sys/net/ipv6/conf/%s/disable_ipv6", l.Attrs().Name)
+		disableIPv6Filename := disableIPv6File(l.Attrs().Name)
 		if err = nshandle.RunIn(forwarderNetNS, targetNetNS, func() error {
+			value, readErr := os.ReadFile(filepath.Clean(disableIPv6Filename))
+			if readErr != nil {
+				return readErr
+			}
+			linkstate.SaveSysctl(ctx, isClient, disableIPv6Filename, strings.TrimSpace(string(value)))
 			return os.WriteFile(disableIPv6Filename, []byte("0"), 0o600)
 		}); err != nil {
 			return errors.Wrapf(err, "failed to set %s = 0", disableIPv6Filename)
@@ -129,6 +137,45 @@ func create(ctx context.Context, conn *networkservice.Connection, isClient bool)
 	return nil
 }
 
+func restoreDisableIPv6(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		state, ok := linkstate.Load(ctx, isClient)
+		if !ok {
+			return nil
+		}
+		disableIPv6Filename := disableIPv6File(link.GetInterfaceName(mechanism))
+		value, ok := state.Sysctls[disableIPv6Filename]
+		if !ok {
+			return nil
+		}
+
+		forwarderNetNS, err := nshandle.Current()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = forwarderNetNS.Close() }()
+
+		targetNetNS, err := nshandle.FromURL(mechanism.GetNetNSURL())
+		if err != nil {
+			return err
+		}
+		defer func() { _ = targetNetNS.Close() }()
+
+		if err = nshandle.RunIn(forwarderNetNS, targetNetNS, func() error {
+			return os.WriteFile(disableIPv6Filename, []byte(value), 0o600)
+		}); err != nil {
+			return errors.Wrapf(err, "failed to restore %s = %s", disableIPv6Filename, value)
+		}
+		delete(state.Sysctls, disableIPv6Filename)
+		log.FromContext(ctx).Debugf("%s was restored to %s", disableIPv6Filename, value)
+	}
+	return nil
+}
+
+func disableIPv6File(ifName string) string {
+	return fmt.Sprintf("/proc/sys/net/ipv6/conf/%s/disable_ipv6", ifName)
+}
+
 func removeOldIPAddrs(ctx context.Context, netlinkHandle *netlink.Handle, l netlink.Link, ipAddrs []*net.IPNet) error {
 	for _, ipNet := range ipAddrs {
 		now := time.Now()
@@ -1,4 +1,4 @@
-// Copyright (c) 2022 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
@@ -26,20 +26,26 @@ package ipneighbors
 import (
 	"context"
 
+	"github.com/edwarnicke/genericsync"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 )
 
-type ipNeighborsClient struct{}
+type ipNeighborsClient struct {
+	neighbors *genericsync.Map[string, kernelNeighbors]
+}
 
 // NewClient creates a new client chain element setting IP neighbors to kernel interface
 func NewClient() networkservice.NetworkServiceClient {
-	return &ipNeighborsClient{}
+	return &ipNeighborsClient{
+		neighbors: new(genericsync.Map[string, kernelNeighbors]),
+	}
 }
 
 func (i *ipNeighborsClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
@@ -50,7 +56,7 @@ func (i *ipNeighborsClient) Request(ctx context.Context, request *networkservice
 		return nil, err
 	}
 
-	if err := create(ctx, conn, true); err != nil {
+	if err := create(ctx, conn, true, i.neighbors); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -65,5 +71,8 @@ func (i *ipNeighborsClient) Request(ctx context.Context, request *networkservice
 }
 
 func (i *ipNeighborsClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := del(ctx, conn, i.neighbors); err != nil {
+		log.FromContext(ctx).Errorf("ipNeighborsClient del: %v", err.Error())
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
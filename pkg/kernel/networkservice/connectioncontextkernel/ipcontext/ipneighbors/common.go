@@ -2,7 +2,7 @@
 //
 // Copyright (c) 2022 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2022-2023 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -29,8 +29,10 @@ import (
 	"net"
 	"time"
 
+	"github.com/edwarnicke/genericsync"
 	"github.com/pkg/errors"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
@@ -42,111 +44,173 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+// kernelNeighbors are the neighbors installed for the connection keyed by the neighbor IP
+type kernelNeighbors map[string]*netlink.Neigh
+
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, installed *genericsync.Map[string, kernelNeighbors]) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
 		if err != nil {
 			return err
 		}
 		defer netlinkHandle.Close()
 
-		ifName := mechanism.GetInterfaceName()
+		ifName := link.GetInterfaceName(mechanism)
 		l, err := netlinkHandle.LinkByName(ifName)
 		if err != nil {
 			return errors.Wrapf(err, "failed to find link %s", ifName)
 		}
 
-		if err := setIPContextNeighbors(ctx, netlinkHandle, conn.GetContext().GetIpContext().GetIpNeighbors(), l); err != nil {
+		toAdd, err := getIPContextNeighbors(conn.GetContext().GetIpContext().GetIpNeighbors(), l)
+		if err != nil {
 			return err
 		}
 
 		// If payload is IP - we need to add additional neighbor
 		if conn.GetPayload() == payload.IP {
-			peerLink, ok := peer.Load(ctx, isClient)
-			if !ok {
-				log.FromContext(ctx).Error("Peer link not found")
+			addPeerNeighbors(ctx, conn, isClient, l, toAdd)
+		}
+
+		current, ok := installed.Load(conn.GetId())
+		if !ok {
+			if len(toAdd) == 0 {
 				return nil
 			}
-			if peerLink == nil || peerLink.Attrs() == nil || peerLink.Attrs().HardwareAddr == nil {
-				panic(fmt.Sprintf("unable to construct peer ip neighbor %+v", peerLink))
+			current = make(kernelNeighbors)
+			installed.Store(conn.GetId(), current)
+		}
+
+		// Remove no longer existing neighbors
+		for key, neigh := range current {
+			if _, ok := toAdd[key]; ok {
+				continue
+			}
+			if err := neighDel(ctx, netlinkHandle, neigh); err != nil {
+				return err
 			}
+			delete(current, key)
+		}
 
-			dstNets := conn.GetContext().GetIpContext().GetDstIPNets()
-			if isClient {
-				dstNets = conn.GetContext().GetIpContext().GetSrcIPNets()
+		// Add new neighbors and update the existing ones
+		for key, neigh := range toAdd {
+			if err := neighSet(ctx, netlinkHandle, neigh); err != nil {
+				return err
 			}
+			current[key] = neigh
+		}
+	}
+	return nil
+}
 
-			for _, dstNet := range dstNets {
-				if dstNet != nil {
-					if err := setPeerNeighbor(ctx, netlinkHandle, l, peerLink, dstNet); err != nil {
-						return err
-					}
-				}
+func del(ctx context.Context, conn *networkservice.Connection, installed *genericsync.Map[string, kernelNeighbors]) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		current, ok := installed.LoadAndDelete(conn.GetId())
+		if !ok {
+			return nil
+		}
+
+		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+		if err != nil {
+			return err
+		}
+		defer netlinkHandle.Close()
+
+		for _, neigh := range current {
+			if err := neighDel(ctx, netlinkHandle, neigh); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
-func setIPContextNeighbors(ctx context.Context, handle *netlink.Handle, ipNeighbours []*networkservice.IpNeighbor, netLink netlink.Link) error {
+func getIPContextNeighbors(ipNeighbours []*networkservice.IpNeighbor, netLink netlink.Link) (kernelNeighbors, error) {
+	neighbors := make(kernelNeighbors)
 	for _, ipNeighbor := range ipNeighbours {
 		macAddr, err := net.ParseMAC(ipNeighbor.HardwareAddress)
 		if err != nil {
-			return errors.Wrapf(err, "invalid neighbor MAC address: %v", ipNeighbor.HardwareAddress)
+			return nil, errors.Wrapf(err, "invalid neighbor MAC address: %v", ipNeighbor.HardwareAddress)
 		}
-		now := time.Now()
 		neigh := &netlink.Neigh{
 			LinkIndex:    netLink.Attrs().Index,
 			State:        link.NudReachable,
 			IP:           net.ParseIP(ipNeighbor.Ip),
 			HardwareAddr: macAddr,
 		}
-		if err := handle.NeighSet(neigh); err != nil {
-			log.FromContext(ctx).
-				WithField("linkIndex", neigh.LinkIndex).
-				WithField("ip", neigh.IP).
-				WithField("state", neigh.State).
-				WithField("hardwareAddr", neigh.HardwareAddr).
-				WithField("duration", time.Since(now)).
-				WithField("netlink", "NeighSet").Error("setIPNeighbors failed")
-			return errors.Wrapf(err, "failed to add IP neighbor: %v", ipNeighbor)
+		if neigh.IP == nil {
+			return nil, errors.Errorf("invalid neighbor IP address: %v", ipNeighbor.Ip)
+		}
+		neighbors[neigh.IP.String()] = neigh
+	}
+	return neighbors, nil
+}
+
+func addPeerNeighbors(ctx context.Context, conn *networkservice.Connection, isClient bool, l netlink.Link, neighbors kernelNeighbors) {
+	peerLink, ok := peer.Load(ctx, isClient)
+	if !ok {
+		log.FromContext(ctx).Error("Peer link not found")
+		return
+	}
+	if peerLink == nil || peerLink.Attrs() == nil || peerLink.Attrs().HardwareAddr == nil {
+		panic(fmt.Sprintf("unable to construct peer ip neighbor %+v", peerLink))
+	}
+
+	dstNets := conn.GetContext().GetIpContext().GetDstIPNets()
+	if isClient {
+		dstNets = conn.GetContext().GetIpContext().GetSrcIPNets()
+	}
+
+	for _, dstNet := range dstNets {
+		if dstNet != nil {
+			neighbors[dstNet.IP.String()] = &netlink.Neigh{
+				LinkIndex:    l.Attrs().Index,
+				IP:           dstNet.IP,
+				State:        netlink.NUD_PERMANENT,
+				HardwareAddr: peerLink.Attrs().HardwareAddr,
+			}
 		}
+	}
+}
+
+func neighSet(ctx context.Context, handle *netlink.Handle, neigh *netlink.Neigh) error {
+	now := time.Now()
+	if err := handle.NeighSet(neigh); err != nil {
 		log.FromContext(ctx).
 			WithField("linkIndex", neigh.LinkIndex).
 			WithField("ip", neigh.IP).
 			WithField("state", neigh.State).
 			WithField("hardwareAddr", neigh.HardwareAddr).
 			WithField("duration", time.Since(now)).
-			WithField("netlink", "NeighSet").Debug("setIPNeighbors completed")
+			WithField("netlink", "NeighSet").Error("neighSet failed")
+		return errors.Wrapf(err, "failed to update ARP table with %s %s", neigh.IP.String(), neigh.HardwareAddr.String())
 	}
+	log.FromContext(ctx).
+		WithField("linkIndex", neigh.LinkIndex).
+		WithField("ip", neigh.IP).
+		WithField("state", neigh.State).
+		WithField("hardwareAddr", neigh.HardwareAddr).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "NeighSet").Debug("neighSet completed")
 	return nil
 }
 
-func setPeerNeighbor(ctx context.Context, handle *netlink.Handle, l, peerLink netlink.Link, dstNet *net.IPNet) error {
+func neighDel(ctx context.Context, handle *netlink.Handle, neigh *netlink.Neigh) error {
 	now := time.Now()
-	neigh := &netlink.Neigh{
-		LinkIndex:    l.Attrs().Index,
-		IP:           dstNet.IP,
-		State:        netlink.NUD_PERMANENT,
-		HardwareAddr: peerLink.Attrs().HardwareAddr,
-	}
-
-	if err := handle.NeighSet(neigh); err != nil {
+	// The neighbor may be already deleted by the kernel together with the interface
+	if err := handle.NeighDel(neigh); err != nil && !errors.Is(err, unix.ENOENT) && !errors.Is(err, unix.ENODEV) {
 		log.FromContext(ctx).
 			WithField("linkIndex", neigh.LinkIndex).
 			WithField("ip", neigh.IP).
-			WithField("state", neigh.State).
 			WithField("hardwareAddr", neigh.HardwareAddr).
 			WithField("duration", time.Since(now)).
-			WithField("netlink", "NeighSet").Error("setPeerNeighbor failed")
-		return errors.Wrapf(err, "failed to update ARP table with %s %s", neigh.IP.String(), neigh.HardwareAddr.String())
+			WithField("netlink", "NeighDel").Error("neighDel failed")
+		return errors.Wrapf(err, "failed to delete ARP table entry %s %s", neigh.IP.String(), neigh.HardwareAddr.String())
 	}
 	log.FromContext(ctx).
 		WithField("linkIndex", neigh.LinkIndex).
 		WithField("ip", neigh.IP).
-		WithField("state", neigh.State).
 		WithField("hardwareAddr", neigh.HardwareAddr).
 		WithField("duration", time.Since(now)).
-		WithField("netlink", "NeighSet").Debug("setPeerNeighbor completed")
+		WithField("netlink", "NeighDel").Debug("neighDel completed")
 	return nil
 }
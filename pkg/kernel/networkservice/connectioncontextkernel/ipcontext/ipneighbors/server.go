@@ -2,6 +2,8 @@
 //
 // Copyright (c) 2021-2022 Doc.ai and/or its affiliates.
 //
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -24,19 +26,25 @@ package ipneighbors
 import (
 	"context"
 
+	"github.com/edwarnicke/genericsync"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 )
 
-type ipNeighborsServer struct{}
+type ipNeighborsServer struct {
+	neighbors *genericsync.Map[string, kernelNeighbors]
+}
 
 // NewServer creates a new server chain element setting IP neighbors to kernel interface
 func NewServer() networkservice.NetworkServiceServer {
-	return &ipNeighborsServer{}
+	return &ipNeighborsServer{
+		neighbors: new(genericsync.Map[string, kernelNeighbors]),
+	}
 }
 
 func (i *ipNeighborsServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -47,7 +55,7 @@ func (i *ipNeighborsServer) Request(ctx context.Context, request *networkservice
 		return nil, err
 	}
 
-	if err := create(ctx, conn, false); err != nil {
+	if err := create(ctx, conn, false, i.neighbors); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -62,5 +70,8 @@ func (i *ipNeighborsServer) Request(ctx context.Context, request *networkservice
 }
 
 func (i *ipNeighborsServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := del(ctx, conn, i.neighbors); err != nil {
+		log.FromContext(ctx).Errorf("ipNeighborsServer del: %v", err.Error())
+	}
 	return next.Server(ctx).Close(ctx, conn)
 }
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package iprule
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
+)
+
+type ipruleClient struct {
+	tables *genericsync.Map[string, policies]
+	// Protecting route and rule setting with the allocator store
+	// The next table ID is calculated based on a dump
+	// other connection from same client can add new table in parallel
+	allocator *tableid.Allocator
+	attrs     *routeattrs.Attributes
+}
+
+// NewClient creates a new client chain element setting ip rules
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	o := newOptions(opts...)
+
+	return &ipruleClient{
+		tables:    new(genericsync.Map[string, policies]),
+		allocator: newTableIDAllocator(o),
+		attrs:     &o.attrs,
+	}
+}
+
+func (i *ipruleClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = recoverTableIDs(ctx, conn, i.tables, i.allocator)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create(ctx, conn, metadata.IsClient(i), i.tables, i.allocator, i.attrs); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := i.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (i *ipruleClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	_ = del(ctx, conn, i.tables, i.allocator)
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -1,6 +1,6 @@
 // Copyright (c) 2022 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2023-2024 Nordix Foundation.
 //
@@ -29,8 +29,6 @@ import (
 	"strconv"
 	"time"
 
-	"golang.org/x/sys/unix"
-
 	"github.com/edwarnicke/genericsync"
 	"github.com/pkg/errors"
 	"github.com/vishvananda/netlink"
@@ -41,11 +39,19 @@ import (
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 
 	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/kernelroute"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, tableIDs *genericsync.Map[string, policies], nsRTableNextIDToConnID *genericsync.Map[netnsRTableNextID, string]) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, tableIDs *genericsync.Map[string, policies], allocator *tableid.Allocator, attrs *routeattrs.Attributes) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		connAttrs, err := attrs.ForConnection(conn, isClient)
+		if err != nil {
+			return err
+		}
+
 		// Construct the netlink handle for the target namespace for this kernel interface
 		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
 		if err != nil {
@@ -53,7 +59,7 @@ func create(ctx context.Context, conn *networkservice.Connection, tableIDs *gene
 		}
 		defer netlinkHandle.Close()
 
-		ifName := mechanism.GetInterfaceName()
+		ifName := link.GetInterfaceName(mechanism)
 		l, err := netlinkHandle.LinkByName(ifName)
 		if err != nil {
 			return errors.Wrapf(err, "iprule: failed to create policy rules for interface %s", ifName)
@@ -64,7 +70,7 @@ func create(ctx context.Context, conn *networkservice.Connection, tableIDs *gene
 			if len(conn.Context.IpContext.Policies) == 0 {
 				return nil
 			}
-			ps = make(map[int]*networkservice.PolicyRoute)
+			ps = make(map[int]*policyRoute)
 			tableIDs.Store(connID, ps)
 		}
 
@@ -73,13 +79,18 @@ func create(ctx context.Context, conn *networkservice.Connection, tableIDs *gene
 		if err != nil {
 			return errors.Wrapf(err, "iprule: failed to create policy rules in namespace: %s", mechanism.GetNetNSURL())
 		}
+		defer func() { _ = netNS.Close() }()
 
 		// Get policies to add and to remove
-		toAdd, toRemove := getPolicyDifferences(ps, conn.Context.IpContext.Policies)
+		connPolicies := getPolicies(conn)
+		for _, policy := range connPolicies {
+			policy.attrs = connAttrs
+		}
+		toAdd, toRemove := getPolicyDifferences(ps, connPolicies)
 
 		// Remove no longer existing policies
 		for tableID, policy := range toRemove {
-			if errRule := delRule(ctx, netlinkHandle, policy, tableID, l.Attrs().Index, createNetnsRTableNextID(netNS.UniqueId(), tableID), nsRTableNextIDToConnID); errRule != nil {
+			if errRule := delRule(ctx, netlinkHandle, policy, tableID, l.Attrs().Index, netNS.UniqueId(), allocator); errRule != nil {
 				return errRule
 			}
 			delete(ps, tableID)
@@ -88,23 +99,9 @@ func create(ctx context.Context, conn *networkservice.Connection, tableIDs *gene
 
 		// Add new policies
 		for _, policy := range toAdd {
-			var tableID int
-			// get a free table ID until we succeed
-			for {
-				tableID, err = getFreeTableID(ctx, netlinkHandle)
-				if err != nil {
-					return err
-				}
-				nsrtid := createNetnsRTableNextID(netNS.UniqueId(), tableID)
-				storedConnID, _ := nsRTableNextIDToConnID.LoadOrStore(nsrtid, connID)
-				log.FromContext(ctx).
-					WithField("nsrtid", nsrtid).
-					WithField("ConnID", storedConnID).
-					Debug("iprule:createNetnsRTableNextID")
-				if connID == storedConnID {
-					// No other connection adding policy using this free routing table ID
-					break
-				}
+			tableID, err := allocator.Allocate(ctx, netlinkHandle, netNS.UniqueId(), connID)
+			if err != nil {
+				return err
 			}
 			if err := addPolicy(ctx, netlinkHandle, policy, l, ps, tableIDs, tableID, connID); err != nil {
 				return err
@@ -114,14 +111,14 @@ func create(ctx context.Context, conn *networkservice.Connection, tableIDs *gene
 	return nil
 }
 
-func addPolicy(ctx context.Context, netlinkHandle *netlink.Handle, policy *networkservice.PolicyRoute, l netlink.Link, ps policies, tableIDs *genericsync.Map[string, policies], tableID int, connID string) error {
+func addPolicy(ctx context.Context, netlinkHandle *netlink.Handle, policy *policyRoute, l netlink.Link, ps policies, tableIDs *genericsync.Map[string, policies], tableID int, connID string) error {
 	// If policy doesn't contain any route - add default
 	if len(policy.Routes) == 0 {
 		policy.Routes = append(policy.Routes, defaultRoute())
 	}
 
 	for _, route := range policy.Routes {
-		if err := routeAdd(ctx, netlinkHandle, l, route, tableID); err != nil {
+		if err := routeAdd(ctx, netlinkHandle, l, route, tableID, policy.attrs); err != nil {
 			return err
 		}
 	}
@@ -133,12 +130,12 @@ func addPolicy(ctx context.Context, netlinkHandle *netlink.Handle, policy *netwo
 	return nil
 }
 
-func getPolicyDifferences(current map[int]*networkservice.PolicyRoute, newPolicies []*networkservice.PolicyRoute) (toAdd []*networkservice.PolicyRoute, toRemove map[int]*networkservice.PolicyRoute) {
+func getPolicyDifferences(current map[int]*policyRoute, newPolicies []*policyRoute) (toAdd []*policyRoute, toRemove map[int]*policyRoute) {
 	type table struct {
 		tableID     int
-		policyRoute *networkservice.PolicyRoute
+		policyRoute *policyRoute
 	}
-	toRemove = make(map[int]*networkservice.PolicyRoute)
+	toRemove = make(map[int]*policyRoute)
 	currentMap := make(map[string]*table)
 	for tableID, policy := range current {
 		currentMap[policyKey(policy)] = &table{
@@ -158,11 +155,11 @@ func getPolicyDifferences(current map[int]*networkservice.PolicyRoute, newPolici
 	return toAdd, toRemove
 }
 
-func policyKey(policy *networkservice.PolicyRoute) string {
-	return fmt.Sprintf("%s;%s;%s;%s", policy.DstPort, policy.SrcPort, policy.From, policy.Proto)
+func policyKey(policy *policyRoute) string {
+	return fmt.Sprintf("%s;%s;%s;%s;%s;%v", policy.DstPort, policy.SrcPort, policy.From, policy.Proto, policy.selectorsString(), policy.attrs)
 }
 
-func policyToRule(policy *networkservice.PolicyRoute) (*netlink.Rule, error) {
+func policyToRule(policy *policyRoute) (*netlink.Rule, error) {
 	rule := netlink.NewRule()
 	if policy.From != "" {
 		src, err := netlink.ParseIPNet(policy.From)
@@ -192,10 +189,13 @@ func policyToRule(policy *networkservice.PolicyRoute) (*netlink.Rule, error) {
 	if srcPortRange != nil {
 		rule.Sport = netlink.NewRulePortRange(srcPortRange.Start, srcPortRange.End)
 	}
+	if err := policy.applySelectors(rule); err != nil {
+		return nil, err
+	}
 	return rule, nil
 }
 
-func ruleAdd(ctx context.Context, handle *netlink.Handle, policy *networkservice.PolicyRoute, tableID int) error {
+func ruleAdd(ctx context.Context, handle *netlink.Handle, policy *policyRoute, tableID int) error {
 	rule, err := policyToRule(policy)
 	if err != nil {
 		return err
@@ -209,6 +209,7 @@ func ruleAdd(ctx context.Context, handle *netlink.Handle, policy *networkservice
 			WithField("IPProto", policy.Proto).
 			WithField("DstPort", policy.DstPort).
 			WithField("SrcPort", policy.SrcPort).
+			WithField("Selectors", policy.selectorsString()).
 			WithField("Table", tableID).
 			WithField("duration", time.Since(now)).
 			WithField("netlink", "RuleAdd").Errorf("error %+v", err)
@@ -219,6 +220,7 @@ func ruleAdd(ctx context.Context, handle *netlink.Handle, policy *networkservice
 		WithField("IPProto", policy.Proto).
 		WithField("DstPort", policy.DstPort).
 		WithField("SrcPort", policy.SrcPort).
+		WithField("Selectors", policy.selectorsString()).
 		WithField("Table", tableID).
 		WithField("duration", time.Since(now)).
 		WithField("netlink", "RuleAdd").Debug("completed")
@@ -231,58 +233,25 @@ func defaultRoute() *networkservice.Route {
 	}
 }
 
-func routeAdd(ctx context.Context, handle *netlink.Handle, l netlink.Link, route *networkservice.Route, tableID int) error {
-	if route.GetPrefixIPNet() == nil {
-		return errors.New("iprule: kernelRoute prefix must not be nil")
-	}
-	dst := route.GetPrefixIPNet()
-	dst.IP = dst.IP.Mask(dst.Mask)
-	kernelRoute := &netlink.Route{
-		LinkIndex: l.Attrs().Index,
-		Scope:     netlink.SCOPE_UNIVERSE,
-		Dst:       dst,
-		Table:     tableID,
-	}
-
-	gw := route.GetNextHopIP()
-	if gw != nil {
-		kernelRoute.Gw = gw
-		kernelRoute.SetFlag(netlink.FLAG_ONLINK)
+func routeAdd(ctx context.Context, handle *netlink.Handle, l netlink.Link, route *networkservice.Route, tableID int, attrs *routeattrs.Attributes) error {
+	kernelRoute, err := kernelroute.FromRoute(l, tableID, netlink.SCOPE_UNIVERSE, route)
+	if err != nil {
+		return err
 	}
-
-	now := time.Now()
-	if err := handle.RouteReplace(kernelRoute); err != nil {
-		log.FromContext(ctx).
-			WithField("link.Name", l.Attrs().Name).
-			WithField("Dst", kernelRoute.Dst).
-			WithField("Gw", kernelRoute.Gw).
-			WithField("Scope", kernelRoute.Scope).
-			WithField("Flags", kernelRoute.Flags).
-			WithField("Table", tableID).
-			WithField("duration", time.Since(now)).
-			WithField("netlink", "RouteReplace").Errorf("error %+v", err)
-		return errors.Wrap(err, "iprule: failed to add route")
+	if attrs != nil {
+		attrs.Apply(kernelRoute)
 	}
-	log.FromContext(ctx).
-		WithField("link.Name", l.Attrs().Name).
-		WithField("Dst", kernelRoute.Dst).
-		WithField("Gw", kernelRoute.Gw).
-		WithField("Scope", kernelRoute.Scope).
-		WithField("Flags", kernelRoute.Flags).
-		WithField("Table", tableID).
-		WithField("duration", time.Since(now)).
-		WithField("netlink", "RouteReplace").Debug("completed")
-	return nil
+	return kernelroute.Replace(ctx, handle, l.Attrs().Name, kernelRoute)
 }
 
-func del(ctx context.Context, conn *networkservice.Connection, tableIDs *genericsync.Map[string, policies], nsRTableNextIDToConnID *genericsync.Map[netnsRTableNextID, string]) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+func del(ctx context.Context, conn *networkservice.Connection, tableIDs *genericsync.Map[string, policies], allocator *tableid.Allocator) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
 		if err != nil {
 			return err
 		}
 		defer netlinkHandle.Close()
-		ifName := mechanism.GetInterfaceName()
+		ifName := link.GetInterfaceName(mechanism)
 		l, err := netlinkHandle.LinkByName(ifName)
 		if err != nil {
 			return errors.Wrapf(err, "iprule: failed to delete policy rules for interface %s", ifName)
@@ -293,8 +262,9 @@ func del(ctx context.Context, conn *networkservice.Connection, tableIDs *generic
 			if err != nil {
 				return errors.Wrapf(err, "iprule: failed to delete policy rules in namespace: %s", mechanism.GetNetNSURL())
 			}
+			defer func() { _ = netNS.Close() }()
 			for tableID, policy := range ps {
-				if err := delRule(ctx, netlinkHandle, policy, tableID, l.Attrs().Index, createNetnsRTableNextID(netNS.UniqueId(), tableID), nsRTableNextIDToConnID); err != nil {
+				if err := delRule(ctx, netlinkHandle, policy, tableID, l.Attrs().Index, netNS.UniqueId(), allocator); err != nil {
 					return err
 				}
 			}
@@ -303,7 +273,7 @@ func del(ctx context.Context, conn *networkservice.Connection, tableIDs *generic
 	return nil
 }
 
-func delRuleOnly(ctx context.Context, handle *netlink.Handle, policy *networkservice.PolicyRoute) error {
+func delRuleOnly(ctx context.Context, handle *netlink.Handle, policy *policyRoute) error {
 	rule, err := policyToRule(policy)
 	if err != nil {
 		return err
@@ -315,6 +285,7 @@ func delRuleOnly(ctx context.Context, handle *netlink.Handle, policy *networkser
 			WithField("IPProto", policy.Proto).
 			WithField("DstPort", policy.DstPort).
 			WithField("SrcPort", policy.SrcPort).
+			WithField("Selectors", policy.selectorsString()).
 			WithField("duration", time.Since(now)).
 			WithField("netlink", "RuleDel").Errorf("error %+v", err)
 		return errors.Wrapf(err, "iprule: failed to delete rule")
@@ -324,14 +295,15 @@ func delRuleOnly(ctx context.Context, handle *netlink.Handle, policy *networkser
 		WithField("IPProto", policy.Proto).
 		WithField("DstPort", policy.DstPort).
 		WithField("SrcPort", policy.SrcPort).
+		WithField("Selectors", policy.selectorsString()).
 		WithField("duration", time.Since(now)).
 		WithField("netlink", "RuleDel").Debug("completed")
 	return nil
 }
 
-func delRule(ctx context.Context, handle *netlink.Handle, policy *networkservice.PolicyRoute, tableID, linkIndex int, nsRTableKey netnsRTableNextID, nsRTableNextIDToConnID *genericsync.Map[netnsRTableNextID, string]) (err error) {
+func delRule(ctx context.Context, handle *netlink.Handle, policy *policyRoute, tableID, linkIndex int, netNS string, allocator *tableid.Allocator) (err error) {
 	if err = flushTable(ctx, handle, tableID, linkIndex); err == nil {
-		nsRTableNextIDToConnID.Delete(nsRTableKey)
+		err = allocator.Release(netNS, tableID)
 	}
 	if errDelRule := delRuleOnly(ctx, handle, policy); errDelRule != nil {
 		return errDelRule
@@ -372,42 +344,3 @@ func flushTable(ctx context.Context, handle *netlink.Handle, tableID, linkIndex
 		WithField("iprule", "flushTable").Debug("completed")
 	return nil
 }
-
-func getFreeTableID(ctx context.Context, handle *netlink.Handle) (int, error) {
-	routes, err := handle.RouteListFiltered(netlink.FAMILY_ALL,
-		&netlink.Route{
-			Table: unix.RT_TABLE_UNSPEC,
-		},
-		netlink.RT_FILTER_TABLE)
-	if err != nil {
-		return 0, errors.Wrapf(err, "iprule: failed to get free routing table ID, no routes")
-	}
-
-	rules, err := handle.RuleList(netlink.FAMILY_ALL)
-	if err != nil {
-		return 0, errors.Wrapf(err, "iprule: failed to get free routing table ID, no rules")
-	}
-
-	// tableID = 0 is reserved
-	takenTableIDs := make(map[int]struct{})
-	for i := 0; i < len(routes); i++ {
-		takenTableIDs[routes[i].Table] = struct{}{}
-	}
-
-	for i := 0; i < len(rules); i++ {
-		takenTableIDs[rules[i].Table] = struct{}{}
-	}
-
-	// Find first missing table id
-	tableID := 1
-	for ; tableID < len(takenTableIDs); tableID++ {
-		if _, ok := takenTableIDs[tableID]; !ok {
-			break
-		}
-	}
-	log.FromContext(ctx).
-		WithField("tableID", tableID).
-		WithField("iprule", "getFreeTableID").Debug("completed")
-
-	return tableID, nil
-}
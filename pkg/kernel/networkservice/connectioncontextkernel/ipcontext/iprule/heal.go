@@ -1,6 +1,6 @@
 // Copyright (c) 2022 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2021-2024 Nordix Foundation.
 //
@@ -24,6 +24,7 @@ package iprule
 
 import (
 	"context"
+	"math"
 
 	"github.com/edwarnicke/genericsync"
 	"github.com/pkg/errors"
@@ -35,10 +36,11 @@ import (
 
 	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
 )
 
-func recoverTableIDs(ctx context.Context, conn *networkservice.Connection, tableIDs *genericsync.Map[string, policies], nsRTableNextIDToConnID *genericsync.Map[netnsRTableNextID, string]) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+func recoverTableIDs(ctx context.Context, conn *networkservice.Connection, tableIDs *genericsync.Map[string, policies], allocator *tableid.Allocator) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		_, ok := tableIDs.Load(conn.GetId())
 		if ok {
 			return nil
@@ -54,7 +56,7 @@ func recoverTableIDs(ctx context.Context, conn *networkservice.Connection, table
 		}
 		defer netlinkHandle.Close()
 
-		ifName := mechanism.GetInterfaceName()
+		ifName := link.GetInterfaceName(mechanism)
 		l, err := netlinkHandle.LinkByName(ifName)
 		if err != nil {
 			return errors.Wrapf(err, "iprule: failed to recover table IDs for interface: %s", ifName)
@@ -65,38 +67,80 @@ func recoverTableIDs(ctx context.Context, conn *networkservice.Connection, table
 			return errors.Wrapf(err, "iprule: failed to recover table IDs in namespace: %s", mechanism.GetNetNSURL())
 		}
 
-		tableIDtoPolicyMap := make(map[int]*networkservice.PolicyRoute)
-		// try to find the corresponding missing policies in the network namespace of the pod
-		for _, policy := range conn.Context.IpContext.Policies {
-			policyRule, err := policyToRule(policy)
-			if err != nil {
-				return err
-			}
-			for i := range podRules {
-				if ruleEquals(&podRules[i], policyRule) {
-					tableIDtoPolicyMap[podRules[i].Table] = policy
-					log.FromContext(ctx).
-						WithField("From", policy.From).
-						WithField("IPProto", policy.Proto).
-						WithField("DstPort", policy.DstPort).
-						WithField("SrcPort", policy.SrcPort).
-						WithField("Table", podRules[i].Table).Debug("policy recovered")
-					break
-				}
-			}
+		netNS, err := nshandle.FromURL(mechanism.GetNetNSURL())
+		if err != nil {
+			return errors.Wrapf(err, "iprule: failed to recover table IDs in namespace: %s", mechanism.GetNetNSURL())
 		}
+		defer func() { _ = netNS.Close() }()
 
-		return deleteRemainders(ctx, netlinkHandle, tableIDtoPolicyMap, podRules, l, mechanism.GetNetNSURL(), nsRTableNextIDToConnID)
+		// The tables allocated for the connection are known, so there is no need to look for the policies
+		owned, err := allocator.Owned(netNS.UniqueId(), conn.GetId())
+		if err != nil {
+			return err
+		}
+		if len(owned) != 0 {
+			return deleteOwnedTables(ctx, netlinkHandle, owned, podRules, l, netNS.UniqueId(), allocator)
+		}
+
+		tableIDtoPolicyMap, err := findPolicyTables(ctx, getPolicies(conn), podRules)
+		if err != nil {
+			return err
+		}
+
+		return deleteRemainders(ctx, netlinkHandle, tableIDtoPolicyMap, podRules, l, netNS.UniqueId(), allocator)
 	}
 	return nil
 }
 
-func deleteRemainders(ctx context.Context, netlinkHandle *netlink.Handle, tableIDtoPolicyMap map[int]*networkservice.PolicyRoute, podRules []netlink.Rule, l netlink.Link, mechanismNetNSURL string, nsRTableNextIDToConnID *genericsync.Map[netnsRTableNextID, string]) error {
-	// Get netns for key to namespace to routing tableID map
-	netNS, err := nshandle.FromURL(mechanismNetNSURL)
-	if err != nil {
-		return err
+// findPolicyTables tries to find the corresponding missing policies in the network namespace of the pod
+func findPolicyTables(ctx context.Context, policies []*policyRoute, podRules []netlink.Rule) (map[int]*policyRoute, error) {
+	tableIDtoPolicyMap := make(map[int]*policyRoute)
+	for _, policy := range policies {
+		policyRule, err := policyToRule(policy)
+		if err != nil {
+			return nil, err
+		}
+		for i := range podRules {
+			if ruleEquals(&podRules[i], policyRule) {
+				tableIDtoPolicyMap[podRules[i].Table] = policy
+				log.FromContext(ctx).
+					WithField("From", policy.From).
+					WithField("IPProto", policy.Proto).
+					WithField("DstPort", policy.DstPort).
+					WithField("SrcPort", policy.SrcPort).
+					WithField("Selectors", policy.selectorsString()).
+					WithField("Table", podRules[i].Table).Debug("policy recovered")
+				break
+			}
+		}
+	}
+	return tableIDtoPolicyMap, nil
+}
+
+func deleteOwnedTables(ctx context.Context, netlinkHandle *netlink.Handle, owned map[int]struct{}, podRules []netlink.Rule, l netlink.Link, netNS string, allocator *tableid.Allocator) error {
+	for tableID := range owned {
+		for i := range podRules {
+			if podRules[i].Table != tableID {
+				continue
+			}
+			if err := netlinkHandle.RuleDel(&podRules[i]); err != nil {
+				return errors.Wrapf(err, "iprule: failed to delete rule for table %d", tableID)
+			}
+			log.FromContext(ctx).
+				WithField("Table", tableID).
+				WithField("netlink", "RuleDel").Debug("completed")
+		}
+		if err := flushTable(ctx, netlinkHandle, tableID, l.Attrs().Index); err != nil {
+			return err
+		}
+		if err := allocator.Release(netNS, tableID); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+func deleteRemainders(ctx context.Context, netlinkHandle *netlink.Handle, tableIDtoPolicyMap map[int]*policyRoute, podRules []netlink.Rule, l netlink.Link, netNS string, allocator *tableid.Allocator) error {
 	for tableID, policy := range tableIDtoPolicyMap {
 		usage := 0
 		for i := range podRules {
@@ -105,7 +149,7 @@ func deleteRemainders(ctx context.Context, netlinkHandle *netlink.Handle, tableI
 			}
 		}
 		if usage == 1 {
-			err := delRule(ctx, netlinkHandle, policy, tableID, l.Attrs().Index, createNetnsRTableNextID(netNS.UniqueId(), tableID), nsRTableNextIDToConnID)
+			err := delRule(ctx, netlinkHandle, policy, tableID, l.Attrs().Index, netNS, allocator)
 			if err != nil {
 				return err
 			}
@@ -123,7 +167,39 @@ func ruleEquals(a, b *netlink.Rule) bool {
 	if a == nil || b == nil {
 		return a == nil && b == nil
 	}
-	return a.Src.String() == b.Src.String() && a.IPProto == b.IPProto && rulePortRangeEquals(a.Dport, b.Dport) && rulePortRangeEquals(a.Sport, b.Sport)
+	return a.Src.String() == b.Src.String() && a.IPProto == b.IPProto && rulePortRangeEquals(a.Dport, b.Dport) && rulePortRangeEquals(a.Sport, b.Sport) &&
+		a.Dst.String() == b.Dst.String() && a.Tos == b.Tos && a.IifName == b.IifName && a.OifName == b.OifName &&
+		a.Mark == b.Mark && ruleMaskEquals(a, b) && rulePriorityEquals(a, b) && ruleUIDRangeEquals(a.UIDRange, b.UIDRange)
+}
+
+// ruleMaskEquals compares fwmark masks, the kernel reports the full mask for the rules added with fwmark only
+func ruleMaskEquals(a, b *netlink.Rule) bool {
+	maskOf := func(r *netlink.Rule) uint32 {
+		if r.Mask != nil {
+			return *r.Mask
+		}
+		if r.Mark != 0 {
+			return math.MaxUint32
+		}
+		return 0
+	}
+	return maskOf(a) == maskOf(b)
+}
+
+// rulePriorityEquals compares priorities, the rule without explicit priority matches any priority
+func rulePriorityEquals(a, b *netlink.Rule) bool {
+	return a.Priority < 0 || b.Priority < 0 || a.Priority == b.Priority
+}
+
+func ruleUIDRangeEquals(a, b *netlink.RuleUIDRange) bool {
+	// The kernel reports the full UID range for the rules added without it
+	isFull := func(r *netlink.RuleUIDRange) bool {
+		return r == nil || (r.Start == 0 && r.End == math.MaxUint32)
+	}
+	if isFull(a) || isFull(b) {
+		return isFull(a) && isFull(b)
+	}
+	return a.Start == b.Start && a.End == b.End
 }
 
 func rulePortRangeEquals(a, b *netlink.RulePortRange) bool {
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package iprule
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
+)
+
+const (
+	defaultMinTableID = 1
+	defaultMaxTableID = math.MaxInt32
+)
+
+// TableIDStore stores the owner connection IDs of the routing tables allocated in the network namespaces
+type TableIDStore = tableid.Store
+
+// NewMemoryTableIDStore returns TableIDStore keeping the tables in memory
+func NewMemoryTableIDStore() TableIDStore {
+	return tableid.NewMemoryStore()
+}
+
+// NewFileTableIDStore returns TableIDStore keeping the tables in the JSON file, so they survive the restarts
+func NewFileTableIDStore(path string) TableIDStore {
+	return tableid.NewFileStore(path)
+}
+
+type options struct {
+	minTableID int
+	maxTableID int
+	store      TableIDStore
+	attrs      routeattrs.Attributes
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithTableIDRange sets the range of the routing table IDs the policies can use. Default is [1, MaxInt32]. It panics
+// if the range is empty or contains the reserved 0 table ID.
+func WithTableIDRange(minTableID, maxTableID int) Option {
+	if minTableID < defaultMinTableID || minTableID > maxTableID {
+		panic(fmt.Sprintf("iprule: invalid routing table ID range [%d, %d]", minTableID, maxTableID))
+	}
+	return func(o *options) {
+		o.minTableID = minTableID
+		o.maxTableID = maxTableID
+	}
+}
+
+// WithTableIDStore sets the store of the allocated routing table IDs. Default is tableid.DefaultStore shared with the
+// vrf elements. Use NewFileTableIDStore to keep the table IDs across the restarts, the same store should be passed to
+// the vrf elements working in the same network namespaces.
+func WithTableIDStore(store TableIDStore) Option {
+	return func(o *options) {
+		o.store = store
+	}
+}
+
+// WithMetric sets the metric of the policy routes, it can be overridden by routeattrs.MetricKey in the connection ExtraContext
+func WithMetric(metric int) Option {
+	return func(o *options) {
+		o.attrs.Metric = metric
+	}
+}
+
+// WithMTU sets the MTU of the policy routes, it can be overridden by routeattrs.MTUKey in the connection ExtraContext
+func WithMTU(mtu int) Option {
+	return func(o *options) {
+		o.attrs.MTU = mtu
+	}
+}
+
+// WithAdvMSS sets the advertised MSS of the policy routes, it can be overridden by routeattrs.AdvMSSKey in the connection ExtraContext
+func WithAdvMSS(advMSS int) Option {
+	return func(o *options) {
+		o.attrs.AdvMSS = advMSS
+	}
+}
+
+// WithProtocol sets the protocol of the policy routes, so the routes added by NSM can be found by the protocol
+func WithProtocol(protocol netlink.RouteProtocol) Option {
+	return func(o *options) {
+		o.attrs.Protocol = protocol
+	}
+}
+
+// WithPreferredSource sets the preferred source of the policy routes to the connection IP address of the same family.
+// The preferred source can also be set by routeattrs.SrcKey in the connection ExtraContext.
+func WithPreferredSource() Option {
+	return func(o *options) {
+		o.attrs.PreferredSource = true
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		minTableID: defaultMinTableID,
+		maxTableID: defaultMaxTableID,
+		store:      tableid.DefaultStore(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func newTableIDAllocator(o *options) *tableid.Allocator {
+	return tableid.NewAllocator(o.minTableID, o.maxTableID, o.store)
+}
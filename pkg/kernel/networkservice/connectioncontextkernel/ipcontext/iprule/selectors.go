@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package iprule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
+)
+
+// Rule selectors which are not a part of networkservice.PolicyRoute yet. They can be set with the connection
+// labels or with the mechanism parameters using SelectorKey or PolicySelectorKey keys.
+const (
+	// FwMark - firewall mark with optional mask: "0x10" or "0x10/0xff"
+	FwMark = "fwmark"
+	// TOS - type of service byte, the kernel accepts only the bits of the IPv4 TOS mask for IPv4 rules: "0x10"
+	TOS = "tos"
+	// To - destination prefix: "10.0.0.0/24"
+	To = "to"
+	// IIF - input interface name
+	IIF = "iif"
+	// OIF - output interface name
+	OIF = "oif"
+	// Priority - rule priority (preference)
+	Priority = "priority"
+	// UIDRange - user ID range: "1000-2000" or "1000"
+	UIDRange = "uidrange"
+
+	selectorKeyPrefix = "iprule."
+)
+
+var selectors = []string{FwMark, TOS, To, IIF, OIF, Priority, UIDRange}
+
+// SelectorKey returns label/mechanism parameter key for the selector applied to all the connection policies
+func SelectorKey(selector string) string {
+	return selectorKeyPrefix + selector
+}
+
+// PolicySelectorKey returns label/mechanism parameter key for the selector applied to the connection policy
+// with the given index in IPContext.Policies
+func PolicySelectorKey(index int, selector string) string {
+	return fmt.Sprintf("%s%d.%s", selectorKeyPrefix, index, selector)
+}
+
+// policyRoute is networkservice.PolicyRoute with the additional rule selectors
+type policyRoute struct {
+	*networkservice.PolicyRoute
+	selectors map[string]string
+	attrs     *routeattrs.Attributes
+}
+
+// getPolicies returns the connection policies with the selectors. Mechanism parameters override labels, policy
+// specific selectors override the ones set for all the policies.
+func getPolicies(conn *networkservice.Connection) []*policyRoute {
+	sources := []map[string]string{conn.GetLabels(), conn.GetMechanism().GetParameters()}
+
+	var result []*policyRoute
+	for i, policy := range conn.GetContext().GetIpContext().GetPolicies() {
+		p := &policyRoute{
+			PolicyRoute: policy,
+			selectors:   make(map[string]string),
+		}
+		for _, source := range sources {
+			for _, selector := range selectors {
+				if value, ok := source[SelectorKey(selector)]; ok {
+					p.selectors[selector] = value
+				}
+				if value, ok := source[PolicySelectorKey(i, selector)]; ok {
+					p.selectors[selector] = value
+				}
+			}
+		}
+		result = append(result, p)
+	}
+
+	return result
+}
+
+func (p *policyRoute) selectorsString() string {
+	var pairs []string
+	for selector, value := range p.selectors {
+		pairs = append(pairs, selector+"="+value)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// applySelectors sets the rule fields from the policy selectors
+func (p *policyRoute) applySelectors(rule *netlink.Rule) error {
+	for selector, value := range p.selectors {
+		if err := applySelector(rule, selector, value); err != nil {
+			return errors.Wrapf(err, "failed to parse %s selector value %s", selector, value)
+		}
+	}
+
+	return nil
+}
+
+func applySelector(rule *netlink.Rule, selector, value string) error {
+	switch selector {
+	case FwMark:
+		return applyFwMark(rule, value)
+	case TOS:
+		tos, err := strconv.ParseUint(value, 0, 8)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rule.Tos = uint(tos)
+	case To:
+		dst, err := netlink.ParseIPNet(value)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rule.Dst = dst
+	case IIF:
+		rule.IifName = value
+	case OIF:
+		rule.OifName = value
+	case Priority:
+		priority, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rule.Priority = int(priority)
+	case UIDRange:
+		return applyUIDRange(rule, value)
+	}
+
+	return nil
+}
+
+func applyFwMark(rule *netlink.Rule, value string) error {
+	markStr, maskStr, hasMask := strings.Cut(value, "/")
+	mark, err := strconv.ParseUint(markStr, 0, 32)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	rule.Mark = uint32(mark)
+
+	if hasMask {
+		mask, err := strconv.ParseUint(maskStr, 0, 32)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rule.Mask = new(uint32)
+		*rule.Mask = uint32(mask)
+	}
+
+	return nil
+}
+
+func applyUIDRange(rule *netlink.Rule, value string) error {
+	startStr, endStr, isRange := strings.Cut(value, "-")
+	if !isRange {
+		endStr = startStr
+	}
+
+	start, err := strconv.ParseUint(startStr, 10, 32)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	end, err := strconv.ParseUint(endStr, 10, 32)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	rule.UIDRange = netlink.NewRuleUIDRange(uint32(start), uint32(end))
+
+	return nil
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2022 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2022 Doc.ai and/or its affiliates.
 //
@@ -29,25 +29,33 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
 )
 
-type policies map[int]*networkservice.PolicyRoute
+type policies map[int]*policyRoute
 
 type ipruleServer struct {
 	tables *genericsync.Map[string, policies]
-	// Protecting route and rule setting with this sync.Map
+	// Protecting route and rule setting with the allocator store
 	// The next table ID is calculated based on a dump
 	// other connection from same client can add new table in parallel
-	nsRTableNextIDToConnID *genericsync.Map[netnsRTableNextID, string]
+	allocator *tableid.Allocator
+	attrs     *routeattrs.Attributes
 }
 
 // NewServer creates a new server chain element setting ip rules
-func NewServer() networkservice.NetworkServiceServer {
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := newOptions(opts...)
+
 	return &ipruleServer{
-		tables:                 new(genericsync.Map[string, policies]),
-		nsRTableNextIDToConnID: new(genericsync.Map[netnsRTableNextID, string]),
+		tables:    new(genericsync.Map[string, policies]),
+		allocator: newTableIDAllocator(o),
+		attrs:     &o.attrs,
 	}
 }
 
@@ -59,12 +67,12 @@ func (i *ipruleServer) Request(ctx context.Context, request *networkservice.Netw
 		return nil, err
 	}
 
-	err = recoverTableIDs(ctx, conn, i.tables, i.nsRTableNextIDToConnID)
+	err = recoverTableIDs(ctx, conn, i.tables, i.allocator)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := create(ctx, conn, i.tables, i.nsRTableNextIDToConnID); err != nil {
+	if err := create(ctx, conn, metadata.IsClient(i), i.tables, i.allocator, i.attrs); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -79,6 +87,6 @@ func (i *ipruleServer) Request(ctx context.Context, request *networkservice.Netw
 }
 
 func (i *ipruleServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	_ = del(ctx, conn, i.tables, i.nsRTableNextIDToConnID)
+	_ = del(ctx, conn, i.tables, i.allocator)
 	return next.Server(ctx).Close(ctx, conn)
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2020-2022 Cisco and/or its affiliates.
+// Copyright (c) 2020-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
@@ -26,6 +26,7 @@ package routes
 import (
 	"context"
 
+	"github.com/edwarnicke/genericsync"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
@@ -33,10 +34,17 @@ import (
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
 )
 
-type routesClient struct{}
+type routesClient struct {
+	routes    *genericsync.Map[string, kernelRoutes]
+	multipath *multipathRoutes
+	attrs     *routeattrs.Attributes
+}
 
 // NewClient creates a NetworkServiceClient that will put the routes from the connection context into
 //
@@ -60,8 +68,19 @@ type routesClient struct{}
 //	                                          |                           |
 //	|                               |         |                           |
 //	+- - - - - - - - - - - - - - - -+         +---------------------------+
-func NewClient() networkservice.NetworkServiceClient {
-	return &routesClient{}
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	o := newOptions(opts...)
+
+	var multipath *multipathRoutes
+	if o.multipath {
+		multipath = newMultipathRoutes()
+	}
+
+	return &routesClient{
+		routes:    new(genericsync.Map[string, kernelRoutes]),
+		multipath: multipath,
+		attrs:     &o.attrs,
+	}
 }
 
 func (i *routesClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
@@ -72,7 +91,7 @@ func (i *routesClient) Request(ctx context.Context, request *networkservice.Netw
 		return nil, err
 	}
 
-	if err := create(ctx, conn, metadata.IsClient(i)); err != nil {
+	if err := create(ctx, conn, metadata.IsClient(i), i.routes, i.multipath, i.attrs); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -87,6 +106,9 @@ func (i *routesClient) Request(ctx context.Context, request *networkservice.Netw
 }
 
 func (i *routesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	// We do not have to delete routes here because the kernel deletes routes for us when we delete the interface
+	// The interface may outlive the connection (e.g. a VF moved back to the host netns), so we delete routes explicitly
+	if err := del(ctx, conn, i.routes, i.multipath); err != nil {
+		log.FromContext(ctx).Errorf("routesClient del: %v", err.Error())
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -1,6 +1,6 @@
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
-// Copyright (c) 2020-2023 Cisco and/or its affiliates.
+// Copyright (c) 2020-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -23,9 +23,9 @@ package routes
 
 import (
 	"context"
-	"time"
+	"fmt"
 
-	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/edwarnicke/genericsync"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
@@ -33,17 +33,28 @@ import (
 	"github.com/vishvananda/netlink"
 
 	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/kernelroute"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
 )
 
-func create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+// kernelRoutes are the routes installed for the connection keyed by the route destination
+type kernelRoutes map[string]*netlink.Route
+
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, installed *genericsync.Map[string, kernelRoutes], multipath *multipathRoutes, attrs *routeattrs.Attributes) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		connAttrs, err := attrs.ForConnection(conn, isClient)
+		if err != nil {
+			return err
+		}
+
 		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
 		if err != nil {
 			return err
 		}
 		defer netlinkHandle.Close()
 
-		ifName := mechanism.GetInterfaceName()
+		ifName := link.GetInterfaceName(mechanism)
 		l, err := netlinkHandle.LinkByName(ifName)
 		if err != nil {
 			return errors.Wrapf(err, "failed to find link %s", ifName)
@@ -53,6 +64,11 @@ func create(ctx context.Context, conn *networkservice.Connection, isClient bool)
 			return errors.Wrapf(err, "failed to setup link for the interface %v", l)
 		}
 
+		mp, err := getMultipathConn(conn, mechanism, multipath)
+		if err != nil {
+			return err
+		}
+
 		var linkRoutes []*networkservice.Route
 		var routes []*networkservice.Route
 		if isClient {
@@ -62,58 +78,133 @@ func create(ctx context.Context, conn *networkservice.Connection, isClient bool)
 			linkRoutes = conn.GetContext().GetIpContext().GetDstIPRoutes()
 			routes = conn.GetContext().GetIpContext().GetSrcRoutesWithExplicitNextHop()
 		}
+
+		toAdd := make(kernelRoutes)
 		for _, route := range linkRoutes {
-			if err := routeAdd(ctx, netlinkHandle, l, netlink.SCOPE_LINK, route); err != nil {
+			kernelRoute, err := toKernelRoute(l, netlink.SCOPE_LINK, route, connAttrs)
+			if err != nil {
 				return err
 			}
+			toAdd[routeKey(kernelRoute)] = kernelRoute
 		}
 		for _, route := range routes {
-			if err := routeAdd(ctx, netlinkHandle, l, netlink.SCOPE_UNIVERSE, route); err != nil {
+			kernelRoute, err := toKernelRoute(l, netlink.SCOPE_UNIVERSE, route, connAttrs)
+			if err != nil {
+				return err
+			}
+			toAdd[routeKey(kernelRoute)] = kernelRoute
+		}
+
+		current, ok := installed.Load(conn.GetId())
+		if !ok {
+			if len(toAdd) == 0 {
+				return nil
+			}
+			current = make(kernelRoutes)
+			installed.Store(conn.GetId(), current)
+		}
+
+		// Remove no longer existing routes
+		for key, kernelRoute := range current {
+			if _, ok := toAdd[key]; ok {
+				continue
+			}
+			if err := delRoute(ctx, netlinkHandle, l.Attrs().Name, kernelRoute, mp); err != nil {
+				return err
+			}
+			delete(current, key)
+		}
+
+		// Add new routes and replace the existing ones
+		for key, kernelRoute := range toAdd {
+			if err := addRoute(ctx, netlinkHandle, l, kernelRoute, mp); err != nil {
 				return err
 			}
+			current[key] = kernelRoute
 		}
 	}
 	return nil
 }
 
-func routeAdd(ctx context.Context, handle *netlink.Handle, l netlink.Link, scope netlink.Scope, route *networkservice.Route) error {
-	if route.GetPrefixIPNet() == nil {
-		return errors.New("kernelRoute prefix must not be nil")
+func del(ctx context.Context, conn *networkservice.Connection, installed *genericsync.Map[string, kernelRoutes], multipath *multipathRoutes) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		current, ok := installed.LoadAndDelete(conn.GetId())
+		if !ok {
+			return nil
+		}
+		// The connection nexthops are not needed anymore even if the network namespace is gone, otherwise they are
+		// merged into the routes of the other network namespace with the same inode
+		defer multipath.forget(conn.GetId())
+
+		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+		if err != nil {
+			return err
+		}
+		defer netlinkHandle.Close()
+
+		mp, err := getMultipathConn(conn, mechanism, multipath)
+		if err != nil {
+			return err
+		}
+
+		for _, kernelRoute := range current {
+			if err := delRoute(ctx, netlinkHandle, link.GetInterfaceName(mechanism), kernelRoute, mp); err != nil {
+				return err
+			}
+		}
 	}
-	dst := route.GetPrefixIPNet()
-	dst.IP = dst.IP.Mask(dst.Mask)
-	kernelRoute := &netlink.Route{
-		LinkIndex: l.Attrs().Index,
-		Scope:     scope,
-		Dst:       dst,
+	return nil
+}
+
+// getMultipathConn returns nil if the multipath routes are disabled
+func getMultipathConn(conn *networkservice.Connection, mechanism *kernel.Mechanism, multipath *multipathRoutes) (*multipathConn, error) {
+	if multipath == nil {
+		return nil, nil
 	}
-	gw := route.GetNextHopIP()
-	if gw != nil {
-		kernelRoute.Gw = gw
-		if scope != netlink.SCOPE_LINK {
-			kernelRoute.SetFlag(netlink.FLAG_ONLINK)
-		}
+
+	weight, err := getWeight(conn)
+	if err != nil {
+		return nil, err
 	}
 
-	now := time.Now()
-	if err := handle.RouteReplace(kernelRoute); err != nil {
-		log.FromContext(ctx).
-			WithField("link.Name", l.Attrs().Name).
-			WithField("Dst", kernelRoute.Dst).
-			WithField("Gw", kernelRoute.Gw).
-			WithField("Scope", kernelRoute.Scope).
-			WithField("Flags", kernelRoute.Flags).
-			WithField("duration", time.Since(now)).
-			WithField("netlink", "RouteReplace").Errorf("error %+v", err)
-		return errors.Wrap(err, "failed to add route")
+	netNS, err := nshandle.FromURL(mechanism.GetNetNSURL())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get network namespace: %s", mechanism.GetNetNSURL())
 	}
-	log.FromContext(ctx).
-		WithField("link.Name", l.Attrs().Name).
-		WithField("Dst", kernelRoute.Dst).
-		WithField("Gw", kernelRoute.Gw).
-		WithField("Scope", kernelRoute.Scope).
-		WithField("Flags", kernelRoute.Flags).
-		WithField("duration", time.Since(now)).
-		WithField("netlink", "RouteReplace").Debug("completed")
-	return nil
+	defer func() { _ = netNS.Close() }()
+
+	return &multipathConn{
+		routes: multipath,
+		netNS:  netNS.UniqueId(),
+		connID: conn.GetId(),
+		weight: weight,
+	}, nil
+}
+
+func addRoute(ctx context.Context, handle *netlink.Handle, l netlink.Link, kernelRoute *netlink.Route, mp *multipathConn) error {
+	if mp != nil {
+		return mp.add(ctx, handle, kernelRoute)
+	}
+	return kernelroute.Replace(ctx, handle, l.Attrs().Name, kernelRoute)
+}
+
+func delRoute(ctx context.Context, handle *netlink.Handle, linkName string, kernelRoute *netlink.Route, mp *multipathConn) error {
+	if mp != nil {
+		return mp.del(ctx, handle, linkName, kernelRoute)
+	}
+	return kernelroute.Del(ctx, handle, linkName, kernelRoute)
+}
+
+// routeKey returns the key of the route, the routes with the different metrics are the different kernel routes
+func routeKey(kernelRoute *netlink.Route) string {
+	return fmt.Sprintf("%s metric %d", kernelRoute.Dst, kernelRoute.Priority)
+}
+
+func toKernelRoute(l netlink.Link, scope netlink.Scope, route *networkservice.Route, attrs *routeattrs.Attributes) (*netlink.Route, error) {
+	kernelRoute, err := kernelroute.FromRoute(l, 0, scope, route)
+	if err != nil {
+		return nil, err
+	}
+	attrs.Apply(kernelRoute)
+	return kernelRoute, nil
 }
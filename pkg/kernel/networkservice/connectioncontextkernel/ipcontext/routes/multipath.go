@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package routes
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/kernelroute"
+)
+
+const (
+	// WeightKey is the connection ExtraContext key of the nexthop weight in the multipath routes, 1 by default
+	WeightKey = "routes.weight"
+
+	minWeight = 1
+	maxWeight = 256
+)
+
+type multipathKey struct {
+	netNS string
+	route string
+}
+
+// nexthop is the nexthop of the multipath route added by the connection
+type nexthop struct {
+	connID string
+	route  *netlink.Route
+	info   *netlink.NexthopInfo
+}
+
+// multipathRoutes merges the routes for the same prefix from the different connections in the network namespace
+// into the multipath routes with a nexthop per connection
+type multipathRoutes struct {
+	mu       sync.Mutex
+	nexthops map[multipathKey][]*nexthop
+}
+
+func newMultipathRoutes() *multipathRoutes {
+	return &multipathRoutes{
+		nexthops: make(map[multipathKey][]*nexthop),
+	}
+}
+
+// multipathConn adds and deletes the connection nexthops in the multipath routes
+type multipathConn struct {
+	routes *multipathRoutes
+	netNS  string
+	connID string
+	weight int
+}
+
+func getWeight(conn *networkservice.Connection) (int, error) {
+	value, ok := conn.GetContext().GetExtraContext()[WeightKey]
+	if !ok {
+		return minWeight, nil
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < minWeight || weight > maxWeight {
+		return 0, errors.Errorf("invalid %s: %s, should be in range [%d, %d]", WeightKey, value, minWeight, maxWeight)
+	}
+	return weight, nil
+}
+
+func (c *multipathConn) add(ctx context.Context, handle *netlink.Handle, kernelRoute *netlink.Route) error {
+	c.routes.mu.Lock()
+	defer c.routes.mu.Unlock()
+
+	key := multipathKey{netNS: c.netNS, route: routeKey(kernelRoute)}
+	nh := &nexthop{
+		connID: c.connID,
+		route:  kernelRoute,
+		info: &netlink.NexthopInfo{
+			LinkIndex: kernelRoute.LinkIndex,
+			Hops:      c.weight - 1,
+			Gw:        kernelRoute.Gw,
+			Flags:     kernelRoute.Flags,
+		},
+	}
+
+	nexthops := append([]*nexthop(nil), c.routes.nexthops[key]...)
+	if i := indexOf(nexthops, c.connID); i >= 0 {
+		nexthops[i] = nh
+	} else {
+		nexthops = append(nexthops, nh)
+	}
+
+	// IPv6 device only routes can not be merged by the kernel, so the other connections' paths would be dropped
+	if len(nexthops) > 1 && kernelRoute.Dst.IP.To4() == nil {
+		for _, other := range nexthops {
+			if other.info.Gw == nil {
+				return errors.Errorf("IPv6 route %s without gateway can not be shared by the connections", kernelRoute.Dst)
+			}
+		}
+	}
+
+	if err := multipathRouteReplace(ctx, handle, nexthops); err != nil {
+		return err
+	}
+	c.routes.nexthops[key] = nexthops
+	return nil
+}
+
+func (c *multipathConn) del(ctx context.Context, handle *netlink.Handle, linkName string, kernelRoute *netlink.Route) error {
+	c.routes.mu.Lock()
+	defer c.routes.mu.Unlock()
+
+	key := multipathKey{netNS: c.netNS, route: routeKey(kernelRoute)}
+	nexthops := c.routes.nexthops[key]
+	i := indexOf(nexthops, c.connID)
+	if i < 0 {
+		return nil
+	}
+	nexthops = append(nexthops[:i:i], nexthops[i+1:]...)
+
+	if len(nexthops) == 0 {
+		delete(c.routes.nexthops, key)
+		return kernelroute.Del(ctx, handle, linkName, kernelRoute)
+	}
+	c.routes.nexthops[key] = nexthops
+	return multipathRouteReplace(ctx, handle, nexthops)
+}
+
+// forget drops the connection nexthops without updating the routes, it is used when the network namespace of the
+// connection is gone together with its routes
+func (r *multipathRoutes) forget(connID string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, nexthops := range r.nexthops {
+		i := indexOf(nexthops, connID)
+		if i < 0 {
+			continue
+		}
+		if nexthops = append(nexthops[:i:i], nexthops[i+1:]...); len(nexthops) == 0 {
+			delete(r.nexthops, key)
+			continue
+		}
+		r.nexthops[key] = nexthops
+	}
+}
+
+func indexOf(nexthops []*nexthop, connID string) int {
+	for i, nh := range nexthops {
+		if nh.connID == connID {
+			return i
+		}
+	}
+	return -1
+}
+
+// toMultipathRoute builds the route with the nexthops from all the connections, the route attributes are taken
+// from the last added one
+func toMultipathRoute(nexthops []*nexthop) *netlink.Route {
+	last := nexthops[len(nexthops)-1].route
+	if len(nexthops) == 1 {
+		return last
+	}
+
+	kernelRoute := &netlink.Route{
+		Scope:    netlink.SCOPE_LINK,
+		Dst:      last.Dst,
+		Src:      last.Src,
+		Protocol: last.Protocol,
+		Priority: last.Priority,
+		MTU:      last.MTU,
+		AdvMSS:   last.AdvMSS,
+	}
+	for _, nh := range nexthops {
+		if nh.route.Scope < kernelRoute.Scope {
+			kernelRoute.Scope = nh.route.Scope
+		}
+		kernelRoute.MultiPath = append(kernelRoute.MultiPath, nh.info)
+	}
+	return kernelRoute
+}
+
+func multipathRouteReplace(ctx context.Context, handle *netlink.Handle, nexthops []*nexthop) error {
+	kernelRoute := toMultipathRoute(nexthops)
+
+	now := time.Now()
+	if err := handle.RouteReplace(kernelRoute); err != nil {
+		log.FromContext(ctx).
+			WithField("Dst", kernelRoute.Dst).
+			WithField("Nexthops", len(nexthops)).
+			WithField("Scope", kernelRoute.Scope).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteReplace").Errorf("error %+v", err)
+		return errors.Wrap(err, "failed to add multipath route")
+	}
+	log.FromContext(ctx).
+		WithField("Dst", kernelRoute.Dst).
+		WithField("Nexthops", len(nexthops)).
+		WithField("Scope", kernelRoute.Scope).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "RouteReplace").Debug("completed")
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package routes
+
+import (
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
+)
+
+type options struct {
+	multipath bool
+	attrs     routeattrs.Attributes
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithMultipath merges the routes for the same prefix from the different connections in the network namespace into
+// one multipath route with a nexthop per connection interface. The nexthop weight can be set with the WeightKey in
+// the connection ExtraContext. The IPv6 routes without gateway can not be merged, so the Request sharing them fails.
+func WithMultipath() Option {
+	return func(o *options) {
+		o.multipath = true
+	}
+}
+
+// WithMetric sets the metric of the routes, it can be overridden by routeattrs.MetricKey in the connection ExtraContext
+func WithMetric(metric int) Option {
+	return func(o *options) {
+		o.attrs.Metric = metric
+	}
+}
+
+// WithMTU sets the MTU of the routes, it can be overridden by routeattrs.MTUKey in the connection ExtraContext
+func WithMTU(mtu int) Option {
+	return func(o *options) {
+		o.attrs.MTU = mtu
+	}
+}
+
+// WithAdvMSS sets the advertised MSS of the routes, it can be overridden by routeattrs.AdvMSSKey in the connection ExtraContext
+func WithAdvMSS(advMSS int) Option {
+	return func(o *options) {
+		o.attrs.AdvMSS = advMSS
+	}
+}
+
+// WithProtocol sets the protocol of the routes, so the routes added by NSM can be found by the protocol
+func WithProtocol(protocol netlink.RouteProtocol) Option {
+	return func(o *options) {
+		o.attrs.Protocol = protocol
+	}
+}
+
+// WithPreferredSource sets the preferred source of the routes to the connection IP address of the same family.
+// The preferred source can also be set by routeattrs.SrcKey in the connection ExtraContext.
+func WithPreferredSource() Option {
+	return func(o *options) {
+		o.attrs.PreferredSource = true
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2020-2022 Cisco and/or its affiliates.
+// Copyright (c) 2020-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
@@ -26,16 +26,23 @@ package routes
 import (
 	"context"
 
+	"github.com/edwarnicke/genericsync"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
 )
 
 type routesServer struct {
+	routes    *genericsync.Map[string, kernelRoutes]
+	multipath *multipathRoutes
+	attrs     *routeattrs.Attributes
 }
 
 // NewServer creates a NetworkServiceServer that will put the routes from the connection context into
@@ -60,8 +67,19 @@ type routesServer struct {
 //	                                          |                           |
 //	|                               |         |                           |
 //	+- - - - - - - - - - - - - - - -+         +---------------------------+
-func NewServer() networkservice.NetworkServiceServer {
-	return &routesServer{}
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := newOptions(opts...)
+
+	var multipath *multipathRoutes
+	if o.multipath {
+		multipath = newMultipathRoutes()
+	}
+
+	return &routesServer{
+		routes:    new(genericsync.Map[string, kernelRoutes]),
+		multipath: multipath,
+		attrs:     &o.attrs,
+	}
 }
 
 func (i *routesServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
@@ -72,7 +90,7 @@ func (i *routesServer) Request(ctx context.Context, request *networkservice.Netw
 		return nil, err
 	}
 
-	if err := create(ctx, conn, metadata.IsClient(i)); err != nil {
+	if err := create(ctx, conn, metadata.IsClient(i), i.routes, i.multipath, i.attrs); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -87,6 +105,9 @@ func (i *routesServer) Request(ctx context.Context, request *networkservice.Netw
 }
 
 func (i *routesServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
-	// We do not have to delete routes here because the kernel deletes routes for us when we delete the interface
+	// The interface may outlive the connection (e.g. a VF moved back to the host netns), so we delete routes explicitly
+	if err := del(ctx, conn, i.routes, i.multipath); err != nil {
+		log.FromContext(ctx).Errorf("routesServer del: %v", err.Error())
+	}
 	return next.Server(ctx).Close(ctx, conn)
 }
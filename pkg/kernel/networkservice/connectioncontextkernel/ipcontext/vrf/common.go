@@ -0,0 +1,259 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vrf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/kernelroute"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
+)
+
+const vrfNamePrefix = "nsm-"
+
+// kernelRoutes are the routes installed for the connection keyed by the route destination
+type kernelRoutes map[string]*netlink.Route
+
+// connVRF is the VRF the connection interface is enslaved to
+type connVRF struct {
+	netNSURL string
+	netNS    string
+	name     string
+	ifName   string
+	routes   kernelRoutes
+}
+
+type vrfs struct {
+	*options
+	// Protecting VRF creation and deletion, the table ID is calculated based on a dump and
+	// the VRF can be shared by the connections
+	mu        sync.Mutex
+	conns     map[string]*connVRF
+	allocator *tableid.Allocator
+}
+
+func (v *vrfs) create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	netNS, err := nshandle.FromURL(mechanism.GetNetNSURL())
+	if err != nil {
+		return errors.Wrapf(err, "vrf: failed to get network namespace: %s", mechanism.GetNetNSURL())
+	}
+	defer func() { _ = netNS.Close() }()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	name := v.vrfName(conn)
+	if cv, ok := v.conns[conn.GetId()]; ok && (cv.netNS != netNS.UniqueId() || cv.name != name) {
+		// The connection has been moved to the other VRF
+		if err := v.delLocked(ctx, conn.GetId()); err != nil {
+			return err
+		}
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	ifName := link.GetInterfaceName(mechanism)
+	l, err := netlinkHandle.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "vrf: failed to find link %s", ifName)
+	}
+
+	vrf, err := v.ensureVRF(ctx, netlinkHandle, netNS.UniqueId(), name)
+	if err != nil {
+		return err
+	}
+
+	cv, ok := v.conns[conn.GetId()]
+	if !ok {
+		cv = &connVRF{
+			netNSURL: mechanism.GetNetNSURL(),
+			netNS:    netNS.UniqueId(),
+			name:     name,
+			ifName:   ifName,
+			routes:   make(kernelRoutes),
+		}
+		v.conns[conn.GetId()] = cv
+	}
+
+	if l.Attrs().MasterIndex != vrf.Attrs().Index {
+		now := time.Now()
+		if err := netlinkHandle.LinkSetMasterByIndex(l, vrf.Attrs().Index); err != nil {
+			return errors.Wrapf(err, "vrf: failed to enslave link %s to VRF %s", ifName, name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", ifName).
+			WithField("vrf", name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetMasterByIndex").Debug("completed")
+	}
+
+	if err := netlinkHandle.LinkSetUp(l); err != nil {
+		return errors.Wrapf(err, "vrf: failed to setup link for the interface %v", l)
+	}
+
+	return updateRoutes(ctx, netlinkHandle, l, int(vrf.Table), cv.routes, connRoutes(conn, isClient))
+}
+
+func (v *vrfs) del(ctx context.Context, conn *networkservice.Connection) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	return v.delLocked(ctx, conn.GetId())
+}
+
+func (v *vrfs) delLocked(ctx context.Context, connID string) error {
+	cv, ok := v.conns[connID]
+	if !ok {
+		return nil
+	}
+	delete(v.conns, connID)
+
+	netlinkHandle, err := link.GetNetlinkHandle(cv.netNSURL)
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	for _, kernelRoute := range cv.routes {
+		if err := kernelroute.Del(ctx, netlinkHandle, cv.ifName, kernelRoute); err != nil {
+			return err
+		}
+	}
+
+	vrf, err := netlinkHandle.LinkByName(cv.name)
+	if err == nil {
+		// The interface may outlive the connection, so we release it explicitly
+		if l, err := netlinkHandle.LinkByName(cv.ifName); err == nil && l.Attrs().MasterIndex == vrf.Attrs().Index {
+			if err := netlinkHandle.LinkSetNoMaster(l); err != nil {
+				return errors.Wrapf(err, "vrf: failed to release link %s from VRF %s", cv.ifName, cv.name)
+			}
+		}
+	}
+
+	for _, other := range v.conns {
+		if other.netNS == cv.netNS && other.name == cv.name {
+			return nil
+		}
+	}
+
+	// The VRF may be already deleted, but its table is still allocated
+	if vrf != nil {
+		now := time.Now()
+		if err := netlinkHandle.LinkDel(vrf); err != nil {
+			return errors.Wrapf(err, "vrf: failed to delete VRF %s", cv.name)
+		}
+		log.FromContext(ctx).
+			WithField("vrf", cv.name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkDel").Debug("completed")
+	}
+
+	return v.releaseTables(cv.netNS, cv.name)
+}
+
+// releaseTables releases the routing tables allocated for the VRF
+func (v *vrfs) releaseTables(netNS, name string) error {
+	tableIDs, err := v.allocator.Owned(netNS, name)
+	if err != nil {
+		return errors.Wrapf(err, "vrf: failed to release routing table of VRF %s", name)
+	}
+	for tableID := range tableIDs {
+		if err := v.allocator.Release(netNS, tableID); err != nil {
+			return errors.Wrapf(err, "vrf: failed to release routing table of VRF %s", name)
+		}
+	}
+	return nil
+}
+
+// vrfName returns the VRF name for the connection, it must fit in IFNAMSIZ
+func (v *vrfs) vrfName(conn *networkservice.Connection) string {
+	key := conn.GetId()
+	if v.shared && conn.GetNetworkService() != "" {
+		key = conn.GetNetworkService()
+	}
+	sum := sha256.Sum256([]byte(key))
+	return vrfNamePrefix + hex.EncodeToString(sum[:])[:kernel.LinuxIfMaxLength-len(vrfNamePrefix)]
+}
+
+// ensureVRF returns the existing VRF or creates a new one with a free routing table
+func (v *vrfs) ensureVRF(ctx context.Context, handle *netlink.Handle, netNS, name string) (*netlink.Vrf, error) {
+	l, err := handle.LinkByName(name)
+	if err == nil {
+		vrf, ok := l.(*netlink.Vrf)
+		if !ok {
+			return nil, errors.Errorf("vrf: link %s already exists and it is not a VRF", name)
+		}
+		return vrf, nil
+	}
+	if !errors.As(err, &netlink.LinkNotFoundError{}) {
+		return nil, errors.Wrapf(err, "vrf: failed to find VRF %s", name)
+	}
+
+	// The table is owned by the VRF, so it is shared by the connections enslaved to the VRF
+	tableID, err := v.allocator.Allocate(ctx, handle, netNS, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "vrf: failed to allocate routing table for VRF %s", name)
+	}
+
+	now := time.Now()
+	if err := handle.LinkAdd(&netlink.Vrf{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		Table:     uint32(tableID),
+	}); err != nil {
+		_ = v.allocator.Release(netNS, tableID)
+		return nil, errors.Wrapf(err, "vrf: failed to create VRF %s", name)
+	}
+	log.FromContext(ctx).
+		WithField("vrf", name).
+		WithField("tableID", tableID).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkAdd").Debug("completed")
+
+	if l, err = handle.LinkByName(name); err != nil {
+		return nil, errors.Wrapf(err, "vrf: failed to find VRF %s", name)
+	}
+	if err := handle.LinkSetUp(l); err != nil {
+		return nil, errors.Wrapf(err, "vrf: failed to setup VRF %s", name)
+	}
+
+	return l.(*netlink.Vrf), nil
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vrf provides networkservice chain elements that isolate the connection in its own VRF device inside the
+// network namespace of the kernel interface. It is an alternative to iprule: the connection interface is enslaved
+// to the VRF and the routes from the connection context are installed into the VRF routing table, so connections
+// with overlapping address spaces are separated on L3.
+package vrf
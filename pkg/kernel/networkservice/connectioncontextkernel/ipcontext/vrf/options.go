@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vrf
+
+import (
+	"math"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
+)
+
+const (
+	defaultMinTableID = 1
+	defaultMaxTableID = math.MaxInt32
+)
+
+type options struct {
+	shared     bool
+	minTableID int
+	maxTableID int
+	store      tableid.Store
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithSharedVRF makes all the connections to the same network service in the network namespace share one VRF.
+// By default each connection gets its own VRF.
+func WithSharedVRF() Option {
+	return func(o *options) {
+		o.shared = true
+	}
+}
+
+// WithTableIDRange sets the range of the routing table IDs the VRFs can use. Default is [1, MaxInt32].
+func WithTableIDRange(minTableID, maxTableID int) Option {
+	return func(o *options) {
+		o.minTableID = minTableID
+		o.maxTableID = maxTableID
+	}
+}
+
+// WithTableIDStore sets the store of the allocated routing table IDs. Default is tableid.DefaultStore shared with the
+// iprule elements, so they never allocate the same table. The table is stored with the VRF name as the owner.
+func WithTableIDStore(store tableid.Store) Option {
+	return func(o *options) {
+		o.store = store
+	}
+}
+
+func newVRFs(opts ...Option) *vrfs {
+	o := &options{
+		minTableID: defaultMinTableID,
+		maxTableID: defaultMaxTableID,
+		store:      tableid.DefaultStore(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &vrfs{
+		options:   o,
+		conns:     make(map[string]*connVRF),
+		allocator: tableid.NewAllocator(o.minTableID, o.maxTableID, o.store),
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vrf
+
+import (
+	"context"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/kernelroute"
+)
+
+// connRoutes returns the routes from the connection context with the scope they should be installed with
+func connRoutes(conn *networkservice.Connection, isClient bool) map[*networkservice.Route]netlink.Scope {
+	var linkRoutes []*networkservice.Route
+	var routes []*networkservice.Route
+	if isClient {
+		linkRoutes = conn.GetContext().GetIpContext().GetSrcIPRoutes()
+		routes = conn.GetContext().GetIpContext().GetDstRoutesWithExplicitNextHop()
+	} else {
+		linkRoutes = conn.GetContext().GetIpContext().GetDstIPRoutes()
+		routes = conn.GetContext().GetIpContext().GetSrcRoutesWithExplicitNextHop()
+	}
+
+	result := make(map[*networkservice.Route]netlink.Scope)
+	for _, route := range linkRoutes {
+		result[route] = netlink.SCOPE_LINK
+	}
+	for _, route := range routes {
+		result[route] = netlink.SCOPE_UNIVERSE
+	}
+	return result
+}
+
+// updateRoutes makes the routes installed into the VRF table match the routes from the connection context
+func updateRoutes(ctx context.Context, handle *netlink.Handle, l netlink.Link, tableID int, current kernelRoutes, routes map[*networkservice.Route]netlink.Scope) error {
+	toAdd := make(kernelRoutes)
+	for route, scope := range routes {
+		kernelRoute, err := kernelroute.FromRoute(l, tableID, scope, route)
+		if err != nil {
+			return err
+		}
+		toAdd[routeKey(kernelRoute)] = kernelRoute
+	}
+
+	// Remove no longer existing routes
+	for key, kernelRoute := range current {
+		if _, ok := toAdd[key]; ok {
+			continue
+		}
+		if err := kernelroute.Del(ctx, handle, l.Attrs().Name, kernelRoute); err != nil {
+			return err
+		}
+		delete(current, key)
+	}
+
+	// Add new routes and replace the existing ones
+	for key, kernelRoute := range toAdd {
+		if err := kernelroute.Replace(ctx, handle, l.Attrs().Name, kernelRoute); err != nil {
+			return err
+		}
+		current[key] = kernelRoute
+	}
+	return nil
+}
+
+func routeKey(kernelRoute *netlink.Route) string {
+	return kernelRoute.Dst.String()
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vrf
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type vrfServer struct {
+	vrfs *vrfs
+}
+
+// NewServer creates a NetworkServiceServer that enslaves the kernel interface to the VRF in the endpoint network
+// namespace and puts the routes from the connection context into the VRF table iff the selected mechanism for the
+// connection is a kernel mechanism. It replaces routes.NewServer and iprule.NewServer and should be placed after
+// ipaddress.NewServer in the chain, so the interface is enslaved before the addresses are set.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &vrfServer{
+		vrfs: newVRFs(opts...),
+	}
+}
+
+func (v *vrfServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.vrfs.create(ctx, conn, metadata.IsClient(v)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := v.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (v *vrfServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := v.vrfs.del(ctx, conn); err != nil {
+		log.FromContext(ctx).Errorf("vrfServer del: %v", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -1,5 +1,7 @@
 // Copyright (c) 2022 Xored Software Inc and others.
 //
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -27,23 +29,31 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
-	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 
-	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nattemplate"
 )
 
 type iptablesClient struct {
-	manager IPTablesManager
+	manager Manager
 }
 
-// NewClient - returns a new networkservice.NetworkServiceClient that modify IPTables rules
-// by mechanism provided template on Request and rollbacks rules changes on Close
-func NewClient() networkservice.NetworkServiceClient {
+// NewClient - returns a new networkservice.NetworkServiceClient that applies IPTables rules
+// by mechanism provided template on Request and deletes them on Close. The rules are placed
+// into the connection owned chains, so rules of the other connections and any other iptables
+// changes made in the meantime are left untouched.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		manager: nattemplate.NewIPTablesManager(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &iptablesClient{
-		manager: &iptableManagerImpl{},
+		manager: o.manager,
 	}
 }
 
@@ -55,7 +65,7 @@ func (c *iptablesClient) Request(ctx context.Context, request *networkservice.Ne
 		return nil, err
 	}
 
-	if err := applyIptablesRules(ctx, conn, c); err != nil {
+	if err := applyIptablesRules(ctx, conn, c.manager, metadata.IsClient(c)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -72,32 +82,13 @@ func (c *iptablesClient) Request(ctx context.Context, request *networkservice.Ne
 func (c *iptablesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
 	_, err := next.Client(ctx).Close(ctx, conn, opts...)
 
-	var restoreErr error
-	ctxMap := metadata.Map(ctx, metadata.IsClient(c))
-	if initialRules, rulesWasApplied := ctxMap.Load(applyIPTablesKey{}); rulesWasApplied {
-		mechanism := kernel.ToMechanism(conn.GetMechanism())
-		currentNsHandler, handleErr := nshandle.Current()
-		if handleErr != nil {
-			return nil, handleErr
-		}
-		defer func() { _ = currentNsHandler.Close() }()
-
-		targetHsHandler, handleErr := nshandle.FromURL(mechanism.GetNetNSURL())
-		if handleErr != nil {
-			return nil, handleErr
-		}
-		defer func() { _ = targetHsHandler.Close() }()
-
-		restoreErr = nshandle.RunIn(currentNsHandler, targetHsHandler, func() error {
-			return c.manager.Restore(initialRules.(string))
-		})
-	}
+	deleteErr := deleteIptablesRules(ctx, conn, c.manager, metadata.IsClient(c))
 
-	if err != nil && restoreErr != nil {
-		return nil, errors.Wrap(err, restoreErr.Error())
+	if err != nil && deleteErr != nil {
+		return nil, errors.Wrap(err, deleteErr.Error())
 	}
-	if restoreErr != nil {
-		return nil, restoreErr
+	if deleteErr != nil {
+		return nil, deleteErr
 	}
 
 	return &empty.Empty{}, err
@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package iptables4nattemplate_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/chain"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/iptables4nattemplate"
+)
+
+type testManager struct {
+	applied [][]string
+	deleted []string
+}
+
+func (m *testManager) Apply(rules []string) error {
+	m.applied = append(m.applied, rules)
+	return nil
+}
+
+func (m *testManager) Delete(chainPrefix string) error {
+	m.deleted = append(m.deleted, chainPrefix)
+	return nil
+}
+
+func TestIPTablesClient_ConnectionChains(t *testing.T) {
+	manager := new(testManager)
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		iptables4nattemplate.NewClient(iptables4nattemplate.WithManager(manager)),
+	)
+
+	mechanism := kernel.New("file:///proc/self/ns/net")
+	kernel.ToMechanism(mechanism).SetInterfaceName("nsm-1")
+	kernel.ToMechanism(mechanism).SetIPTables4NatTemplate(
+		"-N NSM_PREROUTE",
+		"-A NSM_PREROUTE -p tcp --dport 80 -j DNAT --to-destination {{ index .NsmDstIPs 0 }}",
+		"-I PREROUTING 1 -i {{ .NsmInterfaceName }} -j NSM_PREROUTE",
+	)
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{
+			Id:        "ac5a3a7e-8d4e-4ad1-9b04-1c9f5e6b3c2a",
+			Mechanism: mechanism,
+			Context: &networkservice.ConnectionContext{
+				IpContext: &networkservice.IPContext{DstIpAddrs: []string{"172.16.0.1/32"}},
+			},
+		},
+	}
+
+	conn, err := client.Request(context.Background(), request)
+	require.NoError(t, err)
+	require.Len(t, manager.applied, 1)
+
+	rules := manager.applied[0]
+	require.Len(t, rules, 5)
+	prefix := strings.TrimSuffix(strings.TrimPrefix(rules[0], "-N "), "NSM_PREROUTE")
+	// The chains left by the previous forwarder are deleted before the apply
+	require.Equal(t, []string{prefix}, manager.deleted)
+	require.LessOrEqual(t, len(prefix+"POSTROUTING"), 28)
+	require.Equal(t, []string{
+		"-N " + prefix + "NSM_PREROUTE",
+		"-A " + prefix + "NSM_PREROUTE -p tcp --dport 80 -j DNAT --to-destination 172.16.0.1",
+		"-N " + prefix + "PREROUTING",
+		"-I " + prefix + "PREROUTING 1 -i nsm-1 -j " + prefix + "NSM_PREROUTE",
+		"-I PREROUTING 1 -j " + prefix + "PREROUTING",
+	}, rules)
+
+	// Refresh with the same rules doesn't touch iptables
+	conn, err = client.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Len(t, manager.applied, 1)
+	require.Equal(t, []string{prefix}, manager.deleted)
+
+	// Refresh with the changed rules replaces the connection chains
+	kernel.ToMechanism(conn.GetMechanism()).SetIPTables4NatTemplate("-A OUTPUT -o nsm-1 -j MASQUERADE")
+	conn, err = client.Request(context.Background(), &networkservice.NetworkServiceRequest{Connection: conn})
+	require.NoError(t, err)
+	require.Equal(t, []string{prefix, prefix}, manager.deleted)
+	require.Equal(t, []string{
+		"-N " + prefix + "OUTPUT",
+		"-A " + prefix + "OUTPUT -o nsm-1 -j MASQUERADE",
+		"-I OUTPUT 1 -j " + prefix + "OUTPUT",
+	}, manager.applied[1])
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, []string{prefix, prefix, prefix}, manager.deleted)
+}
+
+func TestIPTablesClient_Restart(t *testing.T) {
+	manager := new(testManager)
+
+	mechanism := kernel.New("file:///proc/self/ns/net")
+	kernel.ToMechanism(mechanism).SetIPTables4NatTemplate("-A POSTROUTING -j MASQUERADE")
+
+	// Each client has no rules applied in the metadata like the restarted forwarder
+	for i := 0; i < 2; i++ {
+		client := chain.NewNetworkServiceClient(
+			metadata.NewClient(),
+			iptables4nattemplate.NewClient(iptables4nattemplate.WithManager(manager)),
+		)
+		_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+			Connection: &networkservice.Connection{Id: "id", Mechanism: mechanism},
+		})
+		require.NoError(t, err)
+	}
+
+	require.Len(t, manager.applied, 2)
+	require.Equal(t, manager.applied[0], manager.applied[1])
+	prefix := strings.TrimSuffix(strings.TrimPrefix(manager.applied[0][0], "-N "), "POSTROUTING")
+	require.Equal(t, []string{prefix, prefix}, manager.deleted)
+}
+
+func TestIPTablesClient_ForeignChain(t *testing.T) {
+	manager := new(testManager)
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		iptables4nattemplate.NewClient(iptables4nattemplate.WithManager(manager)),
+	)
+
+	mechanism := kernel.New("file:///proc/self/ns/net")
+	kernel.ToMechanism(mechanism).SetIPTables4NatTemplate("-A DOCKER -j RETURN")
+
+	_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id", Mechanism: mechanism},
+	})
+	require.Error(t, err)
+	require.Empty(t, manager.applied)
+}
+
+func TestIPTablesClient_Policy(t *testing.T) {
+	manager := new(testManager)
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		iptables4nattemplate.NewClient(iptables4nattemplate.WithManager(manager)),
+	)
+
+	mechanism := kernel.New("file:///proc/self/ns/net")
+	kernel.ToMechanism(mechanism).SetIPTables4NatTemplate("-P OUTPUT DROP")
+
+	_, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id", Mechanism: mechanism},
+	})
+	require.Error(t, err)
+	require.Empty(t, manager.applied)
+}
+
+func TestIPTablesServer_ConnectionChains(t *testing.T) {
+	manager := new(testManager)
+	server := chain.NewNetworkServiceServer(
+		metadata.NewServer(),
+		iptables4nattemplate.NewServer(iptables4nattemplate.WithManager(manager)),
+	)
+
+	mechanism := kernel.New("file:///proc/self/ns/net")
+	kernel.ToMechanism(mechanism).SetIPTables4NatTemplate("-A POSTROUTING -j MASQUERADE")
+
+	conn, err := server.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id", Mechanism: mechanism},
+	})
+	require.NoError(t, err)
+	require.Len(t, manager.applied, 1)
+	require.Len(t, manager.applied[0], 3)
+
+	_, err = server.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Len(t, manager.deleted, 2)
+}
+
+type testIPTablesManager struct {
+	testManager
+	save string
+}
+
+func (m *testIPTablesManager) Get() (string, error) {
+	return m.save, nil
+}
+
+func TestIPTablesClient_IPTablesManager(t *testing.T) {
+	manager := new(testIPTablesManager)
+	client := chain.NewNetworkServiceClient(
+		metadata.NewClient(),
+		iptables4nattemplate.NewClient(iptables4nattemplate.WithIPTablesManager(manager)),
+	)
+
+	mechanism := kernel.New("file:///proc/self/ns/net")
+	kernel.ToMechanism(mechanism).SetIPTables4NatTemplate("-A POSTROUTING -j MASQUERADE")
+
+	conn, err := client.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection: &networkservice.Connection{Id: "id", Mechanism: mechanism},
+	})
+	require.NoError(t, err)
+	require.Len(t, manager.applied, 1)
+
+	chainName := strings.TrimPrefix(manager.applied[0][0], "-N ")
+	manager.save = strings.Join([]string{
+		"*filter",
+		":" + chainName + " - [0:0]",
+		"COMMIT",
+		"*nat",
+		":POSTROUTING ACCEPT [0:0]",
+		":" + chainName + " - [0:0]",
+		"-A POSTROUTING -j " + chainName,
+		"-A " + chainName + " -j MASQUERADE",
+		"COMMIT",
+	}, "\n")
+
+	_, err = client.Close(context.Background(), conn)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"-D POSTROUTING -j " + chainName,
+		"-F " + chainName,
+		"-X " + chainName,
+	}, manager.applied[1])
+}
@@ -1,6 +1,6 @@
 // Copyright (c) 2022 Xored Software Inc and others.
 //
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -22,154 +22,82 @@
 package iptables4nattemplate
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"os"
 	"strings"
 
-	"github.com/edwarnicke/exechelper"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
-	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 
-	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nattemplate"
 )
 
-type applyIPTablesKey struct {
-}
-
-type iptableManagerImpl struct {
-}
-
 // IPTablesManager provides methods for iptables nat rules management
 type IPTablesManager interface {
 	Get() (string, error)
-	Restore(string) error
 	Apply([]string) error
 }
 
-func (m *iptableManagerImpl) Get() (string, error) {
-	cmdStr := "iptables-save"
-	buf := bytes.NewBuffer([]byte{})
-	err := exechelper.Run(cmdStr,
-		exechelper.WithStdout(buf),
-		exechelper.WithStderr(buf),
-	)
-	if err != nil {
-		return "", errors.Wrapf(err, "%s", buf.String())
-	}
-
-	return buf.String(), nil
-}
-
-func (m *iptableManagerImpl) Apply(rules []string) error {
-	for _, rule := range rules {
-		arguments := strings.Split(rule, " ")
-
-		cmdStr := "iptables -t nat"
-		buf := bytes.NewBuffer([]byte{})
-		err := exechelper.Run(cmdStr,
-			exechelper.WithArgs(arguments...),
-			exechelper.WithStdout(buf),
-			exechelper.WithStderr(buf),
-		)
-		if err != nil {
-			return errors.Wrapf(err, "%s", buf.String())
-		}
-	}
+// Manager applies the rules into the per connection chains and deletes them, see WithManager
+type Manager = nattemplate.Manager
 
-	return nil
+// NewNFTablesManager returns Manager programming the rules over nftables netlink, so no iptables binaries are
+// required
+func NewNFTablesManager() Manager {
+	return nattemplate.NewNFTablesManager()
 }
 
-func (m *iptableManagerImpl) writeTmpRule(rules string) (string, error) {
-	fo, err := os.CreateTemp("/tmp", "rules-*")
-	if err != nil {
-		return "", errors.Wrapf(err, "failed to create new temporary file")
-	}
-
-	defer func() { _ = fo.Close() }()
-	_, err = fo.WriteString(rules)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to write to temporary file")
-	}
-
-	return fo.Name(), nil
+// iptablesManagerAdapter adapts IPTablesManager to Manager: the connection chains are found in the Get output
+type iptablesManagerAdapter struct {
+	IPTablesManager
 }
 
-func (m *iptableManagerImpl) Restore(rules string) error {
-	// Save rules to a temp file
-	tmpFile, err := m.writeTmpRule(rules)
+func (m *iptablesManagerAdapter) Delete(chainPrefix string) error {
+	output, err := m.Get()
 	if err != nil {
 		return err
 	}
 
-	defer func() { _ = os.Remove(tmpFile) }()
-
-	// Restore rules
-	cmdStr := fmt.Sprintf("iptables-restore %s", tmpFile)
-	buf := bytes.NewBuffer([]byte{})
-	err = exechelper.Run(cmdStr,
-		exechelper.WithStdout(buf),
-		exechelper.WithStderr(buf),
-	)
-	if err != nil {
-		return errors.Wrapf(err, "%s", buf.String())
-	}
-
-	return nil
-}
-
-func applyIptablesRules(ctx context.Context, conn *networkservice.Connection, c *iptablesClient) error {
-	ctxMap := metadata.Map(ctx, metadata.IsClient(c))
-	_, rulesWasApplied := ctxMap.Load(applyIPTablesKey{})
-
-	// Check refresh requests
-	if rulesWasApplied {
+	commands := nattemplate.DeleteCommands(getNatTable(output), chainPrefix)
+	if len(commands) == 0 {
 		return nil
 	}
+	return m.Apply(commands)
+}
 
-	mechanism := kernel.ToMechanism(conn.GetMechanism())
-	if mechanism != nil && len(mechanism.GetIPTables4NatTemplate()) != 0 {
-		rules, err := mechanism.EvaluateIPTables4NatTemplate(conn)
-		if err != nil {
-			return errors.WithStack(err)
-		}
-
-		currentNsHandler, err := nshandle.Current()
-		if err != nil {
-			return err
+// getNatTable returns the nat table section of the iptables-save output
+func getNatTable(output string) string {
+	var nat []string
+	inNat := false
+	for _, line := range strings.Split(output, "\n") {
+		switch line = strings.TrimSpace(line); {
+		case strings.HasPrefix(line, "*"):
+			inNat = line == "*nat"
+		case line == "COMMIT":
+			inNat = false
+		case inNat:
+			nat = append(nat, line)
 		}
-		defer func() { _ = currentNsHandler.Close() }()
+	}
+	return strings.Join(nat, "\n")
+}
 
-		targetHsHandler, err := nshandle.FromURL(mechanism.GetNetNSURL())
-		if err != nil {
-			return err
+var natTemplate = nattemplate.Template{
+	Name: "iptables4",
+	Evaluate: func(mechanism *kernel.Mechanism, conn *networkservice.Connection) ([]string, error) {
+		if len(mechanism.GetIPTables4NatTemplate()) == 0 {
+			return nil, nil
 		}
-		defer func() { _ = targetHsHandler.Close() }()
-
-		err = nshandle.RunIn(currentNsHandler, targetHsHandler, func() error {
-			initialRules, iptableErr := c.manager.Get()
-			if iptableErr != nil {
-				return errors.Wrap(iptableErr, "failed to get iptables rules")
-			}
-
-			ctxMap.Store(applyIPTablesKey{}, initialRules)
-
-			iptableErr = c.manager.Apply(rules)
-			if iptableErr != nil {
-				return errors.Wrap(iptableErr, "failed to apply iptables rules")
-			}
-
-			return nil
-		})
+		rules, err := mechanism.EvaluateIPTables4NatTemplate(conn)
+		return rules, errors.WithStack(err)
+	},
+}
 
-		if err != nil {
-			return err
-		}
-	}
+func applyIptablesRules(ctx context.Context, conn *networkservice.Connection, manager Manager, isClient bool) error {
+	return nattemplate.ApplyConnectionRules(ctx, conn, manager, isClient, natTemplate)
+}
 
-	return nil
+func deleteIptablesRules(ctx context.Context, conn *networkservice.Connection, manager Manager, isClient bool) error {
+	return nattemplate.DeleteConnectionRules(ctx, conn, manager, isClient, natTemplate)
 }
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package iptables4nattemplate
+
+type options struct {
+	manager Manager
+}
+
+// Option is an option pattern for NewClient and NewServer
+type Option func(o *options)
+
+// WithManager sets the Manager used to apply the evaluated rules. Default is the iptables binaries based one.
+func WithManager(manager Manager) Option {
+	return func(o *options) {
+		o.manager = manager
+	}
+}
+
+// WithIPTablesManager sets the IPTablesManager used to apply the evaluated rules. The connection chains are deleted
+// with the commands passed to its Apply.
+func WithIPTablesManager(manager IPTablesManager) Option {
+	return func(o *options) {
+		o.manager = &iptablesManagerAdapter{IPTablesManager: manager}
+	}
+}
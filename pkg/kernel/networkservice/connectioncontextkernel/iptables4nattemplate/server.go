@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package iptables4nattemplate
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nattemplate"
+)
+
+type iptablesServer struct {
+	manager Manager
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that applies IPTables rules
+// by mechanism provided template on Request and deletes them on Close. It works the same way
+// as NewClient, but for the kernel interface plugged into the Endpoint.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	o := &options{
+		manager: nattemplate.NewIPTablesManager(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &iptablesServer{
+		manager: o.manager,
+	}
+}
+
+func (s *iptablesServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyIptablesRules(ctx, conn, s.manager, metadata.IsClient(s)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *iptablesServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	deleteErr := deleteIptablesRules(ctx, conn, s.manager, metadata.IsClient(s))
+
+	_, err := next.Server(ctx).Close(ctx, conn)
+
+	if err != nil && deleteErr != nil {
+		return nil, errors.Wrap(err, deleteErr.Error())
+	}
+	if deleteErr != nil {
+		return nil, deleteErr
+	}
+
+	return &empty.Empty{}, err
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package iptables6nattemplate
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nattemplate"
+)
+
+type ip6tablesClient struct {
+	manager Manager
+}
+
+// NewClient - returns a new networkservice.NetworkServiceClient that applies IPv6 nat rules
+// by mechanism provided IPTables6NatTemplate on Request and deletes them on Close. The rules
+// are placed into the connection owned chains the same way iptables4nattemplate does.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	o := &options{
+		manager: nattemplate.NewIP6TablesManager(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &ip6tablesClient{
+		manager: o.manager,
+	}
+}
+
+func (c *ip6tablesClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyIP6TablesRules(ctx, conn, c); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *ip6tablesClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	_, err := next.Client(ctx).Close(ctx, conn, opts...)
+
+	deleteErr := deleteIP6TablesRules(ctx, conn, c)
+
+	if err != nil && deleteErr != nil {
+		return nil, errors.Wrap(err, deleteErr.Error())
+	}
+	if deleteErr != nil {
+		return nil, deleteErr
+	}
+
+	return &empty.Empty{}, err
+}
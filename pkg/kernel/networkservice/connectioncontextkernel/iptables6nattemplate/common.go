@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package iptables6nattemplate
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nattemplate"
+)
+
+// Manager applies the rules into the per connection chains and deletes them, see WithManager
+type Manager = nattemplate.Manager
+
+// NewNFTablesManager returns Manager programming the rules over nftables netlink, so no ip6tables binaries are
+// required
+func NewNFTablesManager() Manager {
+	return nattemplate.NewNFTables6Manager()
+}
+
+var natTemplate = nattemplate.Template{
+	Name: "iptables6",
+	Evaluate: func(mechanism *kernel.Mechanism, conn *networkservice.Connection) ([]string, error) {
+		if len(GetIPTables6NatTemplate(mechanism)) == 0 {
+			return nil, nil
+		}
+		return EvaluateIPTables6NatTemplate(mechanism, conn)
+	},
+}
+
+func applyIP6TablesRules(ctx context.Context, conn *networkservice.Connection, c *ip6tablesClient) error {
+	return nattemplate.ApplyConnectionRules(ctx, conn, c.manager, metadata.IsClient(c), natTemplate)
+}
+
+func deleteIP6TablesRules(ctx context.Context, conn *networkservice.Connection, c *ip6tablesClient) error {
+	return nattemplate.DeleteConnectionRules(ctx, conn, c.manager, metadata.IsClient(c), natTemplate)
+}
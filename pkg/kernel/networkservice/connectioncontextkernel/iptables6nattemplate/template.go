@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package iptables6nattemplate
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+)
+
+const (
+	// IPTables6NatTemplate - kernel mechanism parameter key for the ip6tables ipv6 chains/rules template
+	IPTables6NatTemplate = "IPTables6NatTemplate"
+)
+
+// GetIPTables6NatTemplate - returns ip6tables chain/rules template, nil if unset
+func GetIPTables6NatTemplate(m *kernel.Mechanism) []string {
+	rulesString, ok := m.GetParameters()[IPTables6NatTemplate]
+	if !ok {
+		return nil
+	}
+
+	return strings.Split(rulesString, ";")
+}
+
+// SetIPTables6NatTemplate - sets ip6tables chain/rules template
+func SetIPTables6NatTemplate(m *kernel.Mechanism, rules ...string) {
+	m.GetParameters()[IPTables6NatTemplate] = strings.Join(rules, ";")
+}
+
+// EvaluateIPTables6NatTemplate - evaluates ip6tables chain/rules template with connection parameters. Unlike the
+// IPv4 one, NsmSrcIPs and NsmDstIPs contain only IPv6 addresses, so the template works for dual-stack connections.
+func EvaluateIPTables6NatTemplate(m *kernel.Mechanism, conn *networkservice.Connection) ([]string, error) {
+	type TemplateInput struct {
+		NsmInterfaceName string
+		NsmSrcIPs        []net.IP
+		NsmDstIPs        []net.IP
+	}
+
+	input := TemplateInput{
+		NsmInterfaceName: link.GetInterfaceName(m),
+	}
+
+	for _, srcIPNet := range conn.GetContext().GetIpContext().GetSrcIPNets() {
+		if srcIPNet.IP.To4() == nil {
+			input.NsmSrcIPs = append(input.NsmSrcIPs, srcIPNet.IP)
+		}
+	}
+
+	for _, dstIPNet := range conn.GetContext().GetIpContext().GetDstIPNets() {
+		if dstIPNet.IP.To4() == nil {
+			input.NsmDstIPs = append(input.NsmDstIPs, dstIPNet.IP)
+		}
+	}
+
+	rulesString, ok := m.GetParameters()[IPTables6NatTemplate]
+	if !ok {
+		return nil, errors.New("template is not passed")
+	}
+
+	tmpl, err := template.New("").Parse(rulesString)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse ip6tables template")
+	}
+
+	templateOutput := new(bytes.Buffer)
+	if err := tmpl.Execute(templateOutput, input); err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate ip6tables template")
+	}
+
+	return strings.Split(templateOutput.String(), ";"), nil
+}
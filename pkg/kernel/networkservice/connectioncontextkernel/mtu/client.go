@@ -1,4 +1,4 @@
-// Copyright (c) 2021-2022 Cisco and/or its affiliates.
+// Copyright (c) 2021-2026 Cisco and/or its affiliates.
 //
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
@@ -32,6 +32,7 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
 	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 )
@@ -75,7 +76,7 @@ func (m *mtuClient) Request(ctx context.Context, request *networkservice.Network
 		return nil, err
 	}
 
-	if err := setMTU(ctx, conn); err != nil {
+	if err := setMTU(ctx, conn, metadata.IsClient(m)); err != nil {
 		logger.Debugf("about to Close due to error: %s", err.Error())
 
 		closeCtx, cancelClose := postponeCtxFunc()
@@ -92,5 +93,8 @@ func (m *mtuClient) Request(ctx context.Context, request *networkservice.Network
 }
 
 func (m *mtuClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := restoreMTU(ctx, conn, metadata.IsClient(m)); err != nil {
+		log.FromContext(ctx).Errorf("mtuClient restoreMTU: %v", err.Error())
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
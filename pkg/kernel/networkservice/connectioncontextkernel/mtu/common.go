@@ -1,6 +1,6 @@
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
-// Copyright (c) 2021-2023 Cisco and/or its affiliates.
+// Copyright (c) 2021-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -31,10 +31,11 @@ import (
 	"github.com/pkg/errors"
 
 	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/linkstate"
 )
 
-func setMTU(ctx context.Context, conn *networkservice.Connection) error {
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+func setMTU(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		// Note: These are switched from normal because if we are the client, we need to assign the IP
 		// in the Endpoints NetNS for the Dst.  If we are the *server* we need to assign the IP for the
 		// clients NetNS (ie the source).
@@ -49,7 +50,7 @@ func setMTU(ctx context.Context, conn *networkservice.Connection) error {
 		}
 		defer netlinkHandle.Close()
 
-		ifName := mechanism.GetInterfaceName()
+		ifName := link.GetInterfaceName(mechanism)
 		l, err := netlinkHandle.LinkByName(ifName)
 		if err != nil {
 			return errors.Wrapf(err, "failed to find link %s", ifName)
@@ -59,6 +60,8 @@ func setMTU(ctx context.Context, conn *networkservice.Connection) error {
 			return errors.Wrapf(err, "failed to setup link for the interface %v", l)
 		}
 
+		linkstate.SaveMTU(ctx, isClient, l.Attrs().MTU)
+
 		now := time.Now()
 		if err := netlinkHandle.LinkSetMTU(l, int(mtu)); err != nil {
 			return errors.Wrapf(err, "error attempting to set MTU on link %q to value %q", l.Attrs().Name, mtu)
@@ -71,3 +74,36 @@ func setMTU(ctx context.Context, conn *networkservice.Connection) error {
 	}
 	return nil
 }
+
+func restoreMTU(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		state, ok := linkstate.Load(ctx, isClient)
+		if !ok || state.MTU == 0 {
+			return nil
+		}
+
+		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+		if err != nil {
+			return err
+		}
+		defer netlinkHandle.Close()
+
+		ifName := link.GetInterfaceName(mechanism)
+		l, err := netlinkHandle.LinkByName(ifName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find link %s", ifName)
+		}
+
+		now := time.Now()
+		if err := netlinkHandle.LinkSetMTU(l, state.MTU); err != nil {
+			return errors.Wrapf(err, "error attempting to restore MTU on link %q to value %d", l.Attrs().Name, state.MTU)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("MTU", state.MTU).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetMTU").Debug("completed")
+		state.MTU = 0
+	}
+	return nil
+}
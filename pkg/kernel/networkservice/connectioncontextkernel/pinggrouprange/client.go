@@ -1,4 +1,4 @@
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -42,7 +42,7 @@ func (p *pinggrouprangeClient) Request(ctx context.Context, request *networkserv
 	if err != nil {
 		return nil, err
 	}
-	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil && mechanism.GetVLAN() == 0 {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
 		if err := applyPingGroupRange(ctx, mechanism); err != nil {
 			closeCtx, cancelClose := postponeCtxFunc()
 			defer cancelClose()
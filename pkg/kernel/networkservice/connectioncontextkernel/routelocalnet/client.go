@@ -1,5 +1,7 @@
 // Copyright (c) 2022 Xored Software Inc and others.
 //
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
 // SPDX-License-Identifier: Apache-2.0
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -28,6 +30,8 @@ import (
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
 	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
 )
 
@@ -35,7 +39,7 @@ type routeLocalNetClient struct {
 }
 
 // NewClient - returns a new networkservice.NetworkServiceClient that writes route_localnet flag
-// for network interface on Request if enabled in mechanism
+// for network interface on Request if enabled in mechanism and restores the original value on Close
 func NewClient() networkservice.NetworkServiceClient {
 	return &routeLocalNetClient{}
 }
@@ -48,7 +52,7 @@ func (c *routeLocalNetClient) Request(ctx context.Context, request *networkservi
 		return nil, err
 	}
 
-	if err := setRouteLocalNet(conn); err != nil {
+	if err := setRouteLocalNet(ctx, conn, metadata.IsClient(c)); err != nil {
 		closeCtx, cancelClose := postponeCtxFunc()
 		defer cancelClose()
 
@@ -63,5 +67,8 @@ func (c *routeLocalNetClient) Request(ctx context.Context, request *networkservi
 }
 
 func (c *routeLocalNetClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := restoreRouteLocalNet(ctx, conn, metadata.IsClient(c)); err != nil {
+		log.FromContext(ctx).Errorf("routeLocalNetClient restoreRouteLocalNet: %v", err.Error())
+	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
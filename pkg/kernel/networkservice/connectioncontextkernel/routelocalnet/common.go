@@ -1,6 +1,6 @@
 // Copyright (c) 2022 Xored Software Inc and others.
 //
-// Copyright (c) 2023 Cisco and/or its affiliates.
+// Copyright (c) 2023-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -22,18 +22,22 @@
 package routelocalnet
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/networkservicemesh/api/pkg/api/networkservice"
 	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
 	"github.com/pkg/errors"
 
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/linkstate"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
 )
 
-func setRouteLocalNet(conn *networkservice.Connection) error {
+func setRouteLocalNet(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
 	mechanism := kernel.ToMechanism(conn.GetMechanism())
 	if mechanism != nil && mechanism.GetRouteLocalNet() {
 		currentNsHandler, err := nshandle.Current()
@@ -49,20 +53,14 @@ func setRouteLocalNet(conn *networkservice.Connection) error {
 		defer func() { _ = targetHsHandler.Close() }()
 
 		err = nshandle.RunIn(currentNsHandler, targetHsHandler, func() error {
-			file := filepath.Clean(fmt.Sprintf("/proc/sys/net/ipv4/conf/%s/route_localnet", mechanism.GetInterfaceName()))
-			fo, fileErr := os.Create(file)
+			file := routeLocalNetFile(link.GetInterfaceName(mechanism))
+			value, fileErr := os.ReadFile(file)
 			if fileErr != nil {
-				return errors.Wrapf(fileErr, "failed to create file %s", file)
+				return errors.Wrapf(fileErr, "failed to read file %s", file)
 			}
+			linkstate.SaveSysctl(ctx, isClient, file, strings.TrimSpace(string(value)))
 
-			defer func() { _ = fo.Close() }()
-
-			_, fileErr = fo.WriteString("1")
-			if fileErr != nil {
-				return errors.Wrapf(fileErr, "failed to write to file %s", file)
-			}
-
-			return nil
+			return writeFile(file, "1")
 		})
 
 		if err != nil {
@@ -72,3 +70,62 @@ func setRouteLocalNet(conn *networkservice.Connection) error {
 
 	return nil
 }
+
+func restoreRouteLocalNet(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	state, ok := linkstate.Load(ctx, isClient)
+	if !ok {
+		return nil
+	}
+	file := routeLocalNetFile(link.GetInterfaceName(mechanism))
+	value, ok := state.Sysctls[file]
+	if !ok {
+		return nil
+	}
+
+	currentNsHandler, err := nshandle.Current()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = currentNsHandler.Close() }()
+
+	targetHsHandler, err := nshandle.FromURL(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = targetHsHandler.Close() }()
+
+	err = nshandle.RunIn(currentNsHandler, targetHsHandler, func() error {
+		return writeFile(file, value)
+	})
+	if err != nil {
+		return err
+	}
+	delete(state.Sysctls, file)
+
+	return nil
+}
+
+func routeLocalNetFile(ifName string) string {
+	return filepath.Clean(fmt.Sprintf("/proc/sys/net/ipv4/conf/%s/route_localnet", ifName))
+}
+
+func writeFile(file, value string) error {
+	fo, err := os.Create(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create file %s", file)
+	}
+
+	defer func() { _ = fo.Close() }()
+
+	_, err = fo.WriteString(value)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write to file %s", file)
+	}
+
+	return nil
+}
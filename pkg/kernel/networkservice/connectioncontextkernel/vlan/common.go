@@ -0,0 +1,140 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package vlan
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+)
+
+// createdKey is set in the metadata if the sub-interface has been created for the connection
+type createdKey struct{}
+
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil || mechanism.GetVLAN() == 0 {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	parentName := mechanism.GetInterfaceName()
+	parent, err := netlinkHandle.LinkByName(parentName)
+	if err != nil {
+		return errors.Wrapf(err, "vlan: failed to find link %s", parentName)
+	}
+	if err = netlinkHandle.LinkSetUp(parent); err != nil {
+		return errors.Wrapf(err, "vlan: failed to setup link for the interface %v", parent)
+	}
+
+	vlanID := int(mechanism.GetVLAN())
+	ifName := link.GetInterfaceName(mechanism)
+	l, err := netlinkHandle.LinkByName(ifName)
+	switch {
+	case err == nil:
+		if vlan, ok := l.(*netlink.Vlan); !ok || vlan.VlanId != vlanID || vlan.ParentIndex != parent.Attrs().Index {
+			return errors.Errorf("vlan: link %s already exists and it is not VLAN %d sub-interface of %s", ifName, vlanID, parentName)
+		}
+	case errors.As(err, &netlink.LinkNotFoundError{}):
+		if l, err = addVLAN(ctx, netlinkHandle, ifName, parent, vlanID); err != nil {
+			return err
+		}
+		metadata.Map(ctx, isClient).Store(createdKey{}, struct{}{})
+	default:
+		return errors.Wrapf(err, "vlan: failed to find link %s", ifName)
+	}
+
+	if err = netlinkHandle.LinkSetUp(l); err != nil {
+		return errors.Wrapf(err, "vlan: failed to setup link for the interface %v", l)
+	}
+	return nil
+}
+
+func addVLAN(ctx context.Context, handle *netlink.Handle, ifName string, parent netlink.Link, vlanID int) (netlink.Link, error) {
+	now := time.Now()
+	if err := handle.LinkAdd(&netlink.Vlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:        ifName,
+			ParentIndex: parent.Attrs().Index,
+		},
+		VlanId: vlanID,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "vlan: failed to create VLAN %d sub-interface %s", vlanID, ifName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("parent", parent.Attrs().Name).
+		WithField("VLAN", vlanID).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkAdd").Debug("completed")
+
+	l, err := handle.LinkByName(ifName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "vlan: failed to find link %s", ifName)
+	}
+	return l, nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil || mechanism.GetVLAN() == 0 {
+		return nil
+	}
+	if _, ok := metadata.Map(ctx, isClient).LoadAndDelete(createdKey{}); !ok {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	ifName := link.GetInterfaceName(mechanism)
+	l, err := netlinkHandle.LinkByName(ifName)
+	if err != nil {
+		// The sub-interface is deleted by the kernel together with the parent one
+		return nil
+	}
+
+	now := time.Now()
+	if err := netlinkHandle.LinkDel(l); err != nil {
+		return errors.Wrapf(err, "vlan: failed to delete link %s", ifName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
@@ -2,7 +2,7 @@
 //
 // Copyright (c) 2021-2022 Doc.ai and/or its affiliates.
 //
-// Copyright (c) 2022-2023 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -85,6 +85,8 @@ func (i *vfEthernetClient) Close(ctx context.Context, conn *networkservice.Conne
 		if err := vfCleanup(ctx, vfConfig); err != nil {
 			log.FromContext(ctx).Errorf("vfEthernetClient vfClear: %v", err.Error())
 		}
+	} else if err := restoreKernelHwAddress(ctx, conn, true); err != nil {
+		log.FromContext(ctx).Errorf("vfEthernetClient restoreKernelHwAddress: %v", err.Error())
 	}
 	return next.Client(ctx).Close(ctx, conn, opts...)
 }
@@ -1,6 +1,6 @@
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
-// Copyright (c) 2022-2023 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -35,6 +35,7 @@ import (
 
 	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
 	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/vfconfig"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/linkstate"
 )
 
 func setKernelHwAddress(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
@@ -69,6 +70,7 @@ func setKernelHwAddress(ctx context.Context, conn *networkservice.Connection, is
 				if bytes.Equal([]byte(macAddr), []byte(l.Attrs().HardwareAddr)) {
 					return nil
 				}
+				linkstate.SaveHardwareAddr(ctx, isClient, l.Attrs().HardwareAddr)
 				if err = netlinkHandle.LinkSetDown(l); err != nil {
 					return errors.Wrapf(err, "failed to disable link device %s", l.Attrs().Name)
 				}
@@ -89,6 +91,45 @@ func setKernelHwAddress(ctx context.Context, conn *networkservice.Connection, is
 	return nil
 }
 
+func restoreKernelHwAddress(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	if mechanism := kernel.ToMechanism(conn.GetMechanism()); mechanism != nil {
+		state, ok := linkstate.Load(ctx, isClient)
+		if !ok || state.HardwareAddr == nil {
+			return nil
+		}
+
+		netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+		if err != nil {
+			return err
+		}
+		defer netlinkHandle.Close()
+
+		ifName := mechanism.GetInterfaceName()
+		l, err := netlinkHandle.LinkByName(ifName)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find link %s", ifName)
+		}
+
+		now := time.Now()
+		if err = netlinkHandle.LinkSetDown(l); err != nil {
+			return errors.Wrapf(err, "failed to disable link device %s", l.Attrs().Name)
+		}
+		if err = netlinkHandle.LinkSetHardwareAddr(l, state.HardwareAddr); err != nil {
+			return errors.Wrapf(err, "failed to restore MAC address for the interface: %v", state.HardwareAddr)
+		}
+		if err = netlinkHandle.LinkSetUp(l); err != nil {
+			return errors.Wrapf(err, "failed to setup link for the interface %v", l)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("MACAddr", state.HardwareAddr.String()).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetHardwareAddr").Debug("completed")
+		state.HardwareAddr = nil
+	}
+	return nil
+}
+
 func vfCreate(ctx context.Context, vfConfig *vfconfig.VFConfig, conn *networkservice.Connection, isClient bool) error {
 	pfLink, err := netlink.LinkByName(vfConfig.PFInterfaceName)
 	if err != nil {
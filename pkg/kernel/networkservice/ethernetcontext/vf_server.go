@@ -2,7 +2,7 @@
 //
 // Copyright (c) 2021-2022 Nordix Foundation.
 //
-// Copyright (c) 2022-2023 Cisco and/or its affiliates.
+// Copyright (c) 2022-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -84,6 +84,8 @@ func (s *vfEthernetContextServer) Close(ctx context.Context, conn *networkservic
 		if err := vfCleanup(ctx, vfConfig); err != nil {
 			log.FromContext(ctx).Errorf("vfEthernetContextServer vfClear: %v", err.Error())
 		}
+	} else if err := restoreKernelHwAddress(ctx, conn, false); err != nil {
+		log.FromContext(ctx).Errorf("vfEthernetContextServer restoreKernelHwAddress: %v", err.Error())
 	}
 	return next.Server(ctx).Close(ctx, conn)
 }
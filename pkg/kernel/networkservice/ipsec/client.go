@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsec
+
+import (
+	"context"
+	"crypto/ecdh"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type ipsecClient struct {
+	options *options
+}
+
+// NewClient - returns a new networkservice.NetworkServiceClient that installs ESP tunnel mode XFRM states and
+// policies for the IPSEC mechanism. The client sends its X25519 public key in the mechanism preferences and derives
+// the AEAD keys from the server public key when the connection is established. A new key is generated on each
+// Request, so the connection is rekeyed on refresh. If the rekeying fails on the client side, the Request fails and
+// the connection drops the traffic until it is healed. Everything is removed on Close.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	return &ipsecClient{
+		options: newOptions(opts...),
+	}
+}
+
+func (c *ipsecClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	var privateKey *ecdh.PrivateKey
+	mechanisms := append([]*networkservice.Mechanism{request.GetConnection().GetMechanism()}, request.GetMechanismPreferences()...)
+	for _, m := range mechanisms {
+		mechanism := ipsec.ToMechanism(m)
+		if mechanism == nil {
+			continue
+		}
+		if privateKey == nil {
+			var err error
+			if privateKey, err = generateKey(); err != nil {
+				return nil, err
+			}
+		}
+		mechanism.SetSrcPublicKey(encodeKey(privateKey.PublicKey()))
+		if c.options.endpointIP != nil {
+			mechanism.SetSrcIP(c.options.endpointIP)
+		}
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Other mechanism has been selected
+	if ipsec.ToMechanism(conn.GetMechanism()) == nil || privateKey == nil {
+		if err := del(ctx, metadata.IsClient(c)); err != nil {
+			log.FromContext(ctx).Errorf("ipsecClient del: %v", err.Error())
+		}
+		return conn, nil
+	}
+
+	// The states and policies of the established connection are restored on failure. The server has already committed
+	// the new key and deleted its old states, so the restored ones don't match the server ones: the traffic is dropped
+	// by the policies instead of being sent in plain text until the failed Request is healed and both sides are
+	// rekeyed.
+	isEstablished := loadState(ctx, metadata.IsClient(c)) != nil
+	if err := install(ctx, conn, metadata.IsClient(c), privateKey, c.options); err != nil {
+		if isEstablished {
+			if rollbackErr := rollback(ctx, metadata.IsClient(c)); rollbackErr != nil {
+				err = errors.Wrapf(err, "failed to roll back XFRM states and policies: %s", rollbackErr.Error())
+			}
+			return nil, err
+		}
+
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	if err := commit(ctx, metadata.IsClient(c)); err != nil {
+		log.FromContext(ctx).Errorf("ipsecClient commit: %v", err.Error())
+	}
+
+	return conn, nil
+}
+
+func (c *ipsecClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := del(ctx, metadata.IsClient(c)); err != nil {
+		log.FromContext(ctx).Errorf("ipsecClient del: %v", err.Error())
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
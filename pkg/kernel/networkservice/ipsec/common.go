@@ -0,0 +1,433 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsec
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash/fnv"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const interfaceNamePrefix = "xfrm-"
+
+type stateKey struct{}
+
+// connState is the IPsec configuration of the connection stored in the metadata
+type connState struct {
+	privateKey    *ecdh.PrivateKey
+	peerPublicKey string
+	states        []*netlink.XfrmState
+	policies      []*netlink.XfrmPolicy
+	ifName        string
+	// prev is the configuration of the established connection kept until commit or rollback
+	prev *connState
+}
+
+func loadState(ctx context.Context, isClient bool) *connState {
+	if rawState, ok := metadata.Map(ctx, isClient).Load(stateKey{}); ok {
+		return rawState.(*connState)
+	}
+	return nil
+}
+
+// install installs the XFRM states and policies of the connection derived from the local private key and the peer
+// public key. The states and policies of the established connection are kept until commit or rollback.
+func install(ctx context.Context, conn *networkservice.Connection, isClient bool, privateKey *ecdh.PrivateKey, o *options) error {
+	mechanism := ipsec.ToMechanism(conn.GetMechanism())
+
+	local, remote, peerPublicKey := mechanism.SrcIP(), mechanism.DstIP(), mechanism.DstPublicKey()
+	if !isClient {
+		local, remote, peerPublicKey = remote, local, mechanism.SrcPublicKey()
+	}
+	if local == nil || remote == nil {
+		return errors.Errorf("ipsec: tunnel endpoints are not set in the mechanism parameters: %v", mechanism.GetParameters())
+	}
+
+	out, in, err := deriveSAs(privateKey, peerPublicKey, isClient)
+	if err != nil {
+		return err
+	}
+
+	prev := loadState(ctx, isClient)
+	if prev != nil && prev.prev != nil {
+		// The previous install has been neither committed nor rolled back
+		prev = prev.prev
+	}
+	s := &connState{
+		privateKey:    privateKey,
+		peerPublicKey: peerPublicKey,
+		prev:          prev,
+	}
+	if prev != nil {
+		s.ifName = prev.ifName
+	}
+	// The state is stored before the changes, so rollback and del can clean up after the failure
+	metadata.Map(ctx, isClient).Store(stateKey{}, s)
+
+	reqID := getReqID(conn.GetId())
+	ifID := 0
+	if o.xfrmInterface {
+		s.ifName = getInterfaceName(conn.GetId())
+		if err = ensureInterface(ctx, s.ifName, reqID); err != nil {
+			return err
+		}
+		ifID = reqID
+	}
+
+	s.states = []*netlink.XfrmState{
+		newState(local, remote, out, reqID, ifID),
+		newState(remote, local, in, reqID, ifID),
+	}
+	for _, state := range s.states {
+		if err = stateAdd(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	s.policies = newPolicies(conn, isClient, local, remote, reqID, ifID, o)
+	for _, policy := range s.policies {
+		if err = policyUpdate(ctx, policy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commit deletes the states and policies of the established connection replaced by install
+func commit(ctx context.Context, isClient bool) error {
+	s := loadState(ctx, isClient)
+	if s == nil || s.prev == nil {
+		return nil
+	}
+	prev := s.prev
+	s.prev = nil
+	return deleteStale(ctx, prev, s)
+}
+
+// rollback restores the states and policies of the established connection and deletes the ones added by install.
+// Everything is deleted if the connection has not been established before.
+func rollback(ctx context.Context, isClient bool) error {
+	s := loadState(ctx, isClient)
+	if s == nil {
+		return nil
+	}
+	if s.prev == nil {
+		return del(ctx, isClient)
+	}
+	prev := s.prev
+	metadata.Map(ctx, isClient).Store(stateKey{}, prev)
+
+	// The policies with the same selectors have been replaced by install
+	for _, policy := range prev.policies {
+		if err := policyUpdate(ctx, policy); err != nil {
+			return err
+		}
+	}
+	if err := deleteStale(ctx, s, prev); err != nil {
+		return err
+	}
+	if s.ifName != "" && prev.ifName == "" {
+		return linkDel(ctx, s.ifName)
+	}
+	return nil
+}
+
+// deleteStale deletes the states and policies of stale missing in current
+func deleteStale(ctx context.Context, stale, current *connState) error {
+	for _, state := range stale.states {
+		if !containsState(current.states, state) {
+			if err := stateDel(ctx, state); err != nil {
+				return err
+			}
+		}
+	}
+	for _, policy := range stale.policies {
+		if !containsPolicy(current.policies, policy) {
+			if err := policyDel(ctx, policy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newState(src, dst net.IP, sa *securityAssociation, reqID, ifID int) *netlink.XfrmState {
+	return &netlink.XfrmState{
+		Src:   src,
+		Dst:   dst,
+		Proto: netlink.XFRM_PROTO_ESP,
+		Mode:  netlink.XFRM_MODE_TUNNEL,
+		Spi:   sa.spi,
+		Reqid: reqID,
+		Ifid:  ifID,
+		Aead: &netlink.XfrmStateAlgo{
+			Name:   aeadName,
+			Key:    sa.key,
+			ICVLen: aeadICVLen,
+		},
+	}
+}
+
+// newPolicies returns the out, in and fwd policies for each pair of the local and remote networks of the connection.
+// Policies of the xfrm interface match all the traffic routed through it.
+func newPolicies(conn *networkservice.Connection, isClient bool, local, remote net.IP, reqID, ifID int, o *options) []*netlink.XfrmPolicy {
+	var localNets, remoteNets []*net.IPNet
+	if o.xfrmInterface {
+		for _, cidr := range []string{"0.0.0.0/0", "::/0"} {
+			_, ipNet, _ := net.ParseCIDR(cidr)
+			localNets, remoteNets = append(localNets, ipNet), append(remoteNets, ipNet)
+		}
+	} else {
+		localNets, remoteNets = getNets(conn, isClient), getNets(conn, !isClient)
+	}
+
+	var policies []*netlink.XfrmPolicy
+	for _, localNet := range localNets {
+		for _, remoteNet := range remoteNets {
+			if (localNet.IP.To4() == nil) != (remoteNet.IP.To4() == nil) {
+				continue
+			}
+			policies = append(policies,
+				newPolicy(localNet, remoteNet, netlink.XFRM_DIR_OUT, local, remote, reqID, ifID),
+				newPolicy(remoteNet, localNet, netlink.XFRM_DIR_IN, remote, local, reqID, ifID),
+				newPolicy(remoteNet, localNet, netlink.XFRM_DIR_FWD, remote, local, reqID, ifID))
+		}
+	}
+	return policies
+}
+
+func newPolicy(srcNet, dstNet *net.IPNet, dir netlink.Dir, src, dst net.IP, reqID, ifID int) *netlink.XfrmPolicy {
+	return &netlink.XfrmPolicy{
+		Src:  srcNet,
+		Dst:  dstNet,
+		Dir:  dir,
+		Ifid: ifID,
+		Tmpls: []netlink.XfrmPolicyTmpl{{
+			Src:   src,
+			Dst:   dst,
+			Proto: netlink.XFRM_PROTO_ESP,
+			Mode:  netlink.XFRM_MODE_TUNNEL,
+			Reqid: reqID,
+		}},
+	}
+}
+
+// getNets returns the source (client side) or the destination (server side) addresses and routes of the connection
+func getNets(conn *networkservice.Connection, isClient bool) []*net.IPNet {
+	ipContext := conn.GetContext().GetIpContext()
+
+	ipNets, routes := ipContext.GetDstIPNets(), ipContext.GetDstRoutes()
+	if isClient {
+		ipNets, routes = ipContext.GetSrcIPNets(), ipContext.GetSrcRoutes()
+	}
+
+	var result []*net.IPNet
+	for _, ipNet := range ipNets {
+		if ipNet != nil {
+			result = append(result, &net.IPNet{IP: ipNet.IP.Mask(ipNet.Mask), Mask: ipNet.Mask})
+		}
+	}
+	for _, route := range routes {
+		if ipNet := route.GetPrefixIPNet(); ipNet != nil {
+			result = append(result, ipNet)
+		}
+	}
+	return result
+}
+
+func ensureInterface(ctx context.Context, name string, ifID int) error {
+	if _, err := netlink.LinkByName(name); err == nil {
+		return nil
+	}
+
+	l := &netlink.Xfrmi{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		Ifid:      uint32(ifID),
+	}
+	now := time.Now()
+	if err := netlink.LinkAdd(l); err != nil {
+		return errors.Wrapf(err, "ipsec: failed to create xfrm interface %s", name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", name).
+		WithField("ifID", ifID).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkAdd").Debug("completed")
+
+	if err := netlink.LinkSetUp(l); err != nil {
+		return errors.Wrapf(err, "ipsec: failed to setup link for the interface %s", name)
+	}
+	return nil
+}
+
+func del(ctx context.Context, isClient bool) error {
+	rawState, ok := metadata.Map(ctx, isClient).LoadAndDelete(stateKey{})
+	if !ok {
+		return nil
+	}
+	s := rawState.(*connState)
+
+	// The states and policies of the established connection are kept until commit or rollback
+	for _, cs := range []*connState{s, s.prev} {
+		if cs == nil {
+			continue
+		}
+		for _, policy := range cs.policies {
+			if err := policyDel(ctx, policy); err != nil {
+				return err
+			}
+		}
+		for _, state := range cs.states {
+			if err := stateDel(ctx, state); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.ifName == "" {
+		return nil
+	}
+	return linkDel(ctx, s.ifName)
+}
+
+func linkDel(ctx context.Context, name string) error {
+	l, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil
+	}
+	now := time.Now()
+	if err := netlink.LinkDel(l); err != nil {
+		return errors.Wrapf(err, "ipsec: failed to delete xfrm interface %s", name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
+
+func stateAdd(ctx context.Context, state *netlink.XfrmState) error {
+	now := time.Now()
+	err := netlink.XfrmStateAdd(state)
+	// The state has been already added on the previous Request
+	if errors.Is(err, unix.EEXIST) {
+		err = netlink.XfrmStateUpdate(state)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "ipsec: failed to add xfrm state src %s dst %s spi 0x%08x", state.Src, state.Dst, uint32(state.Spi))
+	}
+	log.FromContext(ctx).
+		WithField("src", state.Src).
+		WithField("dst", state.Dst).
+		WithField("spi", state.Spi).
+		WithField("reqid", state.Reqid).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "XfrmStateAdd").Debug("completed")
+	return nil
+}
+
+func stateDel(ctx context.Context, state *netlink.XfrmState) error {
+	now := time.Now()
+	if err := netlink.XfrmStateDel(state); err != nil && !errors.Is(err, unix.ESRCH) && !errors.Is(err, unix.ENOENT) {
+		return errors.Wrapf(err, "ipsec: failed to delete xfrm state src %s dst %s spi 0x%08x", state.Src, state.Dst, uint32(state.Spi))
+	}
+	log.FromContext(ctx).
+		WithField("src", state.Src).
+		WithField("dst", state.Dst).
+		WithField("spi", state.Spi).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "XfrmStateDel").Debug("completed")
+	return nil
+}
+
+func policyUpdate(ctx context.Context, policy *netlink.XfrmPolicy) error {
+	now := time.Now()
+	if err := netlink.XfrmPolicyUpdate(policy); err != nil {
+		return errors.Wrapf(err, "ipsec: failed to update xfrm policy src %s dst %s %s", policy.Src, policy.Dst, policy.Dir)
+	}
+	log.FromContext(ctx).
+		WithField("src", policy.Src).
+		WithField("dst", policy.Dst).
+		WithField("dir", policy.Dir).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "XfrmPolicyUpdate").Debug("completed")
+	return nil
+}
+
+func policyDel(ctx context.Context, policy *netlink.XfrmPolicy) error {
+	now := time.Now()
+	if err := netlink.XfrmPolicyDel(policy); err != nil && !errors.Is(err, unix.ENOENT) {
+		return errors.Wrapf(err, "ipsec: failed to delete xfrm policy src %s dst %s %s", policy.Src, policy.Dst, policy.Dir)
+	}
+	log.FromContext(ctx).
+		WithField("src", policy.Src).
+		WithField("dst", policy.Dst).
+		WithField("dir", policy.Dir).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "XfrmPolicyDel").Debug("completed")
+	return nil
+}
+
+func containsState(states []*netlink.XfrmState, state *netlink.XfrmState) bool {
+	for _, s := range states {
+		if s.Spi == state.Spi && s.Dst.Equal(state.Dst) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPolicy(policies []*netlink.XfrmPolicy, policy *netlink.XfrmPolicy) bool {
+	for _, p := range policies {
+		if p.Dir == policy.Dir && p.Ifid == policy.Ifid && p.Src.String() == policy.Src.String() && p.Dst.String() == policy.Dst.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// getReqID returns the request ID of the connection states and policies, it is also used as the xfrm interface ID
+func getReqID(connID string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(connID))
+	// Zero request ID matches any state
+	return int(h.Sum32()>>1) | 1
+}
+
+// getInterfaceName returns the name of the xfrm interface for the connection, it must fit in IFNAMSIZ
+func getInterfaceName(connID string) string {
+	sum := sha256.Sum256([]byte(connID))
+	return interfaceNamePrefix + hex.EncodeToString(sum[:])[:kernel.LinuxIfMaxLength-len(interfaceNamePrefix)]
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// ConnectionStates returns the XFRM states installed for the connection in the current network namespace
+func ConnectionStates(connID string) ([]netlink.XfrmState, error) {
+	states, err := netlink.XfrmStateList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, errors.Wrap(err, "ipsec: failed to list xfrm states")
+	}
+
+	reqID := getReqID(connID)
+	var result []netlink.XfrmState
+	for i := range states {
+		if states[i].Reqid == reqID {
+			result = append(result, states[i])
+		}
+	}
+	return result, nil
+}
+
+// ConnectionPolicies returns the XFRM policies installed for the connection in the current network namespace
+func ConnectionPolicies(connID string) ([]netlink.XfrmPolicy, error) {
+	policies, err := netlink.XfrmPolicyList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, errors.Wrap(err, "ipsec: failed to list xfrm policies")
+	}
+
+	reqID := getReqID(connID)
+	var result []netlink.XfrmPolicy
+	for i := range policies {
+		for _, tmpl := range policies[i].Tmpls {
+			if tmpl.Reqid == reqID {
+				result = append(result, policies[i])
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// FormatState formats the state in the `ip xfrm state` style. The keys are not printed.
+func FormatState(state *netlink.XfrmState) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "src %s dst %s\n", state.Src, state.Dst)
+	_, _ = fmt.Fprintf(&sb, "\tproto %s spi 0x%08x reqid %d mode %s\n", state.Proto, uint32(state.Spi), state.Reqid, state.Mode)
+	if state.Aead != nil {
+		_, _ = fmt.Fprintf(&sb, "\taead %s %d\n", state.Aead.Name, state.Aead.ICVLen)
+	}
+	if state.Ifid != 0 {
+		_, _ = fmt.Fprintf(&sb, "\tif_id 0x%x\n", state.Ifid)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// FormatPolicy formats the policy in the `ip xfrm policy` style
+func FormatPolicy(policy *netlink.XfrmPolicy) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "src %s dst %s\n", policy.Src, policy.Dst)
+	_, _ = fmt.Fprintf(&sb, "\t%s priority %d\n", policy.Dir, policy.Priority)
+	if policy.Ifid != 0 {
+		_, _ = fmt.Fprintf(&sb, "\tif_id 0x%x\n", policy.Ifid)
+	}
+	for _, tmpl := range policy.Tmpls {
+		_, _ = fmt.Fprintf(&sb, "\ttmpl src %s dst %s\n", tmpl.Src, tmpl.Dst)
+		_, _ = fmt.Fprintf(&sb, "\t\tproto %s reqid %d mode %s\n", tmpl.Proto, tmpl.Reqid, tmpl.Mode)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
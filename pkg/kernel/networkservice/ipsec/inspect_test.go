@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsec_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/ipsec"
+)
+
+func TestFormatState(t *testing.T) {
+	state := &netlink.XfrmState{
+		Src:   net.ParseIP("10.0.0.1"),
+		Dst:   net.ParseIP("10.0.0.2"),
+		Proto: netlink.XFRM_PROTO_ESP,
+		Mode:  netlink.XFRM_MODE_TUNNEL,
+		Spi:   0x1632393d,
+		Reqid: 5,
+		Ifid:  5,
+		Aead: &netlink.XfrmStateAlgo{
+			Name:   "rfc4106(gcm(aes))",
+			Key:    []byte("secret"),
+			ICVLen: 128,
+		},
+	}
+
+	require.Equal(t, "src 10.0.0.1 dst 10.0.0.2\n"+
+		"\tproto esp spi 0x1632393d reqid 5 mode tunnel\n"+
+		"\taead rfc4106(gcm(aes)) 128\n"+
+		"\tif_id 0x5", ipsec.FormatState(state))
+}
+
+func TestFormatPolicy(t *testing.T) {
+	_, src, _ := net.ParseCIDR("172.16.0.1/32")
+	_, dst, _ := net.ParseCIDR("10.5.0.0/16")
+	policy := &netlink.XfrmPolicy{
+		Src: src,
+		Dst: dst,
+		Dir: netlink.XFRM_DIR_OUT,
+		Tmpls: []netlink.XfrmPolicyTmpl{{
+			Src:   net.ParseIP("10.0.0.1"),
+			Dst:   net.ParseIP("10.0.0.2"),
+			Proto: netlink.XFRM_PROTO_ESP,
+			Mode:  netlink.XFRM_MODE_TUNNEL,
+			Reqid: 5,
+		}},
+	}
+
+	require.Equal(t, "src 172.16.0.1/32 dst 10.5.0.0/16\n"+
+		"\tdir out priority 0\n"+
+		"\ttmpl src 10.0.0.1 dst 10.0.0.2\n"+
+		"\t\tproto esp reqid 5 mode tunnel", ipsec.FormatPolicy(policy))
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsec
+
+import (
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	aeadName   = "rfc4106(gcm(aes))"
+	aeadICVLen = 128
+	// AES-256 key with 4 bytes salt
+	aeadKeyLen = 36
+	spiLen     = 4
+
+	keyInfo = "nsm ipsec"
+)
+
+// securityAssociation is the SPI and the key of one direction of the connection
+type securityAssociation struct {
+	spi int
+	key []byte
+}
+
+func generateKey() (*ecdh.PrivateKey, error) {
+	privateKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "ipsec: failed to generate private key")
+	}
+	return privateKey, nil
+}
+
+func encodeKey(key *ecdh.PublicKey) string {
+	return base64.StdEncoding.EncodeToString(key.Bytes())
+}
+
+// deriveSAs derives the outbound and inbound security associations from X25519 shared secret with HKDF. Both sides
+// of the connection derive the same client to server and server to client associations.
+func deriveSAs(privateKey *ecdh.PrivateKey, peerPublicKeyStr string, isClient bool) (out, in *securityAssociation, err error) {
+	peerPublicKeyBytes, err := base64.StdEncoding.DecodeString(peerPublicKeyStr)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "ipsec: invalid peer public key: %s", peerPublicKeyStr)
+	}
+	peerPublicKey, err := ecdh.X25519().NewPublicKey(peerPublicKeyBytes)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "ipsec: invalid peer public key: %s", peerPublicKeyStr)
+	}
+	secret, err := privateKey.ECDH(peerPublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ipsec: failed to compute shared secret")
+	}
+
+	clientPublicKey, serverPublicKey := privateKey.PublicKey().Bytes(), peerPublicKeyBytes
+	if !isClient {
+		clientPublicKey, serverPublicKey = serverPublicKey, clientPublicKey
+	}
+	info := keyInfo + string(clientPublicKey) + string(serverPublicKey)
+
+	material, err := hkdf.Key(sha256.New, secret, nil, info, 2*(aeadKeyLen+spiLen))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ipsec: failed to derive keys")
+	}
+
+	clientToServer := newSA(material[:aeadKeyLen+spiLen])
+	serverToClient := newSA(material[aeadKeyLen+spiLen:])
+	if isClient {
+		return clientToServer, serverToClient, nil
+	}
+	return serverToClient, clientToServer, nil
+}
+
+func newSA(material []byte) *securityAssociation {
+	// SPI values 1-255 are reserved
+	return &securityAssociation{
+		spi: int(binary.BigEndian.Uint32(material[:spiLen]) | 0x100),
+		key: material[spiLen:],
+	}
+}
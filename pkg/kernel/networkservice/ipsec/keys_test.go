@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveSAs(t *testing.T) {
+	clientKey, err := generateKey()
+	require.NoError(t, err)
+	serverKey, err := generateKey()
+	require.NoError(t, err)
+
+	clientOut, clientIn, err := deriveSAs(clientKey, encodeKey(serverKey.PublicKey()), true)
+	require.NoError(t, err)
+	serverOut, serverIn, err := deriveSAs(serverKey, encodeKey(clientKey.PublicKey()), false)
+	require.NoError(t, err)
+
+	// The client outbound association is the server inbound one and vice versa
+	require.Equal(t, clientOut, serverIn)
+	require.Equal(t, clientIn, serverOut)
+	require.NotEqual(t, clientOut.spi, clientIn.spi)
+	require.Len(t, clientOut.key, aeadKeyLen)
+
+	otherKey, err := generateKey()
+	require.NoError(t, err)
+	otherOut, otherIn, err := deriveSAs(otherKey, encodeKey(serverKey.PublicKey()), true)
+	require.NoError(t, err)
+	require.NotEqual(t, clientOut.spi, otherOut.spi)
+	require.NotEqual(t, clientIn.spi, otherIn.spi)
+	require.NotEqual(t, clientOut.key, otherOut.key)
+}
+
+func TestDeriveSAsInvalidKey(t *testing.T) {
+	privateKey, err := generateKey()
+	require.NoError(t, err)
+
+	_, _, err = deriveSAs(privateKey, "not base64", true)
+	require.Error(t, err)
+	_, _, err = deriveSAs(privateKey, "AAAA", true)
+	require.Error(t, err)
+}
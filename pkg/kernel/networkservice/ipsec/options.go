@@ -0,0 +1,54 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package ipsec
+
+import "net"
+
+type options struct {
+	endpointIP    net.IP
+	xfrmInterface bool
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithEndpointIP sets the local tunnel endpoint IP address sent to the other side in the mechanism parameters. It is
+// required if the IP address is not set by the other chain elements.
+func WithEndpointIP(ip net.IP) Option {
+	return func(o *options) {
+		o.endpointIP = ip
+	}
+}
+
+// WithInterface creates the xfrm interface for the connection. The policies match all the traffic routed through the
+// interface instead of the connection IP context addresses.
+func WithInterface() Option {
+	return func(o *options) {
+		o.xfrmInterface = true
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package ipsec contains chain elements that encrypt the connections with the kernel IPsec (XFRM) ESP tunnels for the
+// IPSEC mechanism. The client and server exchange X25519 public keys through the mechanism parameters and derive
+// rfc4106(gcm(aes)) keys and SPIs for both directions with HKDF.
+package ipsec
+
+import (
+	"context"
+	"crypto/ecdh"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/ipsec"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type ipsecServer struct {
+	options *options
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that installs ESP tunnel mode XFRM states and
+// policies for the IPSEC mechanism. The keys are derived from the client public key in the mechanism parameters,
+// the server public key is sent back to the client. The server generates a new key when the client key is changed on
+// refresh. Everything is removed on Close.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &ipsecServer{
+		options: newOptions(opts...),
+	}
+}
+
+func (s *ipsecServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	mechanism := ipsec.ToMechanism(request.GetConnection().GetMechanism())
+	if mechanism == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	// The key is kept if the client key is the same, e.g. the Request is retried
+	privateKey := s.loadPrivateKey(ctx, mechanism)
+	if privateKey == nil {
+		var err error
+		if privateKey, err = generateKey(); err != nil {
+			return nil, err
+		}
+	}
+	mechanism.SetDstPublicKey(encodeKey(privateKey.PublicKey()))
+	if s.options.endpointIP != nil {
+		mechanism.SetDstIP(s.options.endpointIP)
+	}
+
+	// The states and policies of the established connection are restored on failure
+	if err := install(ctx, request.GetConnection(), metadata.IsClient(s), privateKey, s.options); err != nil {
+		if rollbackErr := rollback(ctx, metadata.IsClient(s)); rollbackErr != nil {
+			err = errors.Wrapf(err, "failed to roll back XFRM states and policies: %s", rollbackErr.Error())
+		}
+		return nil, err
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		rollbackCtx, cancelRollback := postponeCtxFunc()
+		defer cancelRollback()
+
+		if rollbackErr := rollback(rollbackCtx, metadata.IsClient(s)); rollbackErr != nil {
+			err = errors.Wrapf(err, "server request failed, failed to roll back XFRM states and policies: %s", rollbackErr.Error())
+		}
+		return nil, err
+	}
+
+	if err := commit(ctx, metadata.IsClient(s)); err != nil {
+		log.FromContext(ctx).Errorf("ipsecServer commit: %v", err.Error())
+	}
+
+	return conn, nil
+}
+
+func (s *ipsecServer) loadPrivateKey(ctx context.Context, mechanism *ipsec.Mechanism) *ecdh.PrivateKey {
+	if state := loadState(ctx, metadata.IsClient(s)); state != nil && state.peerPublicKey == mechanism.SrcPublicKey() {
+		return state.privateKey
+	}
+	return nil
+}
+
+func (s *ipsecServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if delErr := del(ctx, metadata.IsClient(s)); delErr != nil {
+		log.FromContext(ctx).Errorf("ipsecServer del: %v", delErr.Error())
+	}
+
+	return rv, err
+}
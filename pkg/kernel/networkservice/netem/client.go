@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netem
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type netemClient struct {
+	controller *Controller
+}
+
+// NewClient - returns a new networkservice.NetworkServiceClient that applies the netem qdisc to the kernel interface in
+// the endpoint network namespace iff the selected mechanism for the connection is a kernel mechanism and the impairment
+// is requested by the connection labels or set with Controller. The qdisc is updated on refresh and removed on Close.
+// netem takes the root qdisc of the interface, so it fails if the interface already has the root qdisc set by the
+// other element, e.g. by shaping with the egress limit, and can't be used with the egress shaping in one chain.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	return &netemClient{
+		controller: newOptions(opts...).controller,
+	}
+}
+
+func (c *netemClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create(ctx, conn, c.controller); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *netemClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := del(ctx, conn, c.controller); err != nil {
+		log.FromContext(ctx).Errorf("netemClient del: %v", err.Error())
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
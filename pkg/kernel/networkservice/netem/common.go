@@ -0,0 +1,50 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netem
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+)
+
+func create(ctx context.Context, conn *networkservice.Connection, controller *Controller) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	params, err := getParams(conn)
+	if err != nil {
+		return err
+	}
+	return controller.attach(ctx, conn.GetId(), mechanism.GetNetNSURL(), link.GetInterfaceName(mechanism), params)
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, controller *Controller) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+	return controller.detach(ctx, conn.GetId(), mechanism.GetNetNSURL(), link.GetInterfaceName(mechanism))
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netem
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/rootqdisc"
+)
+
+// rootHandleMajor is the major of the netem root qdisc handle, it differs from the shaping one
+const rootHandleMajor = 2
+
+// connection is the kernel interface of the connection with its netem parameters
+type connection struct {
+	netNSURL  string
+	ifName    string
+	labels    *Params
+	override  *Params
+	installed bool
+}
+
+// Controller changes the netem parameters of the established connections without a refresh. The parameters set with
+// the Controller override the ones from the connection labels until Reset.
+type Controller struct {
+	mu          sync.Mutex
+	connections map[string]*connection
+}
+
+// NewController returns a new Controller to pass to NewClient, NewServer with WithController
+func NewController() *Controller {
+	return &Controller{
+		connections: make(map[string]*connection),
+	}
+}
+
+// Set applies the params to the interface of the connection with connID. Zero params remove the impairment.
+func (c *Controller) Set(ctx context.Context, connID string, params Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, ok := c.connections[connID]
+	if !ok {
+		return errors.Errorf("netem: connection %s not found", connID)
+	}
+	return conn.setOverride(ctx, &params)
+}
+
+// Reset applies the params from the labels of the connection with connID back
+func (c *Controller) Reset(ctx context.Context, connID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, ok := c.connections[connID]
+	if !ok {
+		return errors.Errorf("netem: connection %s not found", connID)
+	}
+	return conn.setOverride(ctx, nil)
+}
+
+// Get returns the params currently applied to the connection with connID
+func (c *Controller) Get(connID string) (Params, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, ok := c.connections[connID]
+	if !ok {
+		return Params{}, false
+	}
+	if params := conn.params(); params != nil {
+		return *params, true
+	}
+	return Params{}, true
+}
+
+func (c *Controller) attach(ctx context.Context, connID, netNSURL, ifName string, labels *Params) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, ok := c.connections[connID]
+	if !ok {
+		// The qdisc may be left before the restart, so it is looked up even if there are no params
+		conn = &connection{installed: true}
+		c.connections[connID] = conn
+	}
+	conn.netNSURL = netNSURL
+	conn.ifName = ifName
+	conn.labels = labels
+	return conn.apply(ctx)
+}
+
+func (c *Controller) detach(ctx context.Context, connID, netNSURL, ifName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, ok := c.connections[connID]
+	if !ok {
+		// The qdisc may be left before the restart, so it is looked up even if the connection is unknown
+		conn = &connection{netNSURL: netNSURL, ifName: ifName, installed: true}
+	}
+	delete(c.connections, connID)
+
+	conn.labels, conn.override = nil, nil
+	return conn.apply(ctx)
+}
+
+// setOverride applies the override or keeps the previous one on failure
+func (conn *connection) setOverride(ctx context.Context, override *Params) error {
+	previous := conn.override
+	conn.override = override
+	if err := conn.apply(ctx); err != nil {
+		conn.override = previous
+		return err
+	}
+	return nil
+}
+
+func (conn *connection) params() *Params {
+	if conn.override != nil {
+		return conn.override
+	}
+	return conn.labels
+}
+
+// apply replaces the root qdisc of the interface with netem or removes it
+func (conn *connection) apply(ctx context.Context) error {
+	params := conn.params()
+	if params.IsZero() && !conn.installed {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(conn.netNSURL)
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	l, err := netlinkHandle.LinkByName(conn.ifName)
+	if err != nil {
+		// The qdisc is deleted by the kernel together with the interface
+		if params.IsZero() {
+			conn.installed = false
+			return nil
+		}
+		return errors.Wrapf(err, "netem: failed to find link %s", conn.ifName)
+	}
+
+	attrs := netlink.QdiscAttrs{
+		LinkIndex: l.Attrs().Index,
+		Handle:    netlink.MakeHandle(rootHandleMajor, 0),
+		Parent:    netlink.HANDLE_ROOT,
+	}
+
+	if params.IsZero() {
+		qdisc, err := rootqdisc.Get(netlinkHandle, l)
+		if err != nil {
+			return errors.Wrap(err, "netem: failed to delete netem qdisc")
+		}
+		// The root qdisc of the other element, e.g. shaping, is not deleted
+		if qdisc != nil && qdisc.Attrs().Handle == attrs.Handle && qdisc.Type() == "netem" {
+			now := time.Now()
+			if err := netlinkHandle.QdiscDel(qdisc); err != nil && !errors.Is(err, unix.ENOENT) {
+				return errors.Wrapf(err, "netem: failed to delete netem qdisc from %s", conn.ifName)
+			}
+			log.FromContext(ctx).
+				WithField("link.Name", conn.ifName).
+				WithField("duration", time.Since(now)).
+				WithField("netlink", "QdiscDel").Debug("completed")
+		}
+		conn.installed = false
+		return nil
+	}
+
+	// The root qdisc of the other element, e.g. shaping, is not replaced silently
+	if _, err := rootqdisc.Check(netlinkHandle, l, attrs.Handle, "netem"); err != nil {
+		return errors.Wrap(err, "netem: failed to set netem qdisc")
+	}
+
+	now := time.Now()
+	if err := netlinkHandle.QdiscReplace(netlink.NewNetem(attrs, params.toNetemAttrs())); err != nil {
+		return errors.Wrapf(err, "netem: failed to set netem qdisc on %s", conn.ifName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", conn.ifName).
+		WithField("params", *params).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "QdiscReplace").Debug("completed")
+	conn.installed = true
+	return nil
+}
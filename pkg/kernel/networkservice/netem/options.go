@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netem
+
+type options struct {
+	controller *Controller
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithController sets the Controller changing the netem parameters of the connections at runtime
+func WithController(controller *Controller) Option {
+	return func(o *options) {
+		o.controller = controller
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		controller: NewController(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package netem
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Connection label keys of the netem parameters
+const (
+	// DelayKey - delay of all the packets: "100ms"
+	DelayKey = "netem.delay"
+	// JitterKey - random variation of the delay: "10ms", requires the delay
+	JitterKey = "netem.jitter"
+	// LossKey - percentage of the dropped packets: "0.5" or "0.5%"
+	LossKey = "netem.loss"
+	// DuplicateKey - percentage of the duplicated packets
+	DuplicateKey = "netem.duplicate"
+	// CorruptKey - percentage of the packets with a single bit error
+	CorruptKey = "netem.corrupt"
+	// ReorderKey - percentage of the packets sent immediately, the rest are delayed, requires the delay
+	ReorderKey = "netem.reorder"
+)
+
+// Params are the netem parameters of the connection interface, the percentages are from 0 to 100
+type Params struct {
+	Delay     time.Duration
+	Jitter    time.Duration
+	Loss      float32
+	Duplicate float32
+	Corrupt   float32
+	Reorder   float32
+}
+
+// IsZero returns true if no impairment is set
+func (p *Params) IsZero() bool {
+	return p == nil || *p == Params{}
+}
+
+// Validate returns an error if the parameters can't be applied
+func (p *Params) Validate() error {
+	if p.IsZero() {
+		return nil
+	}
+	if p.Delay < 0 || p.Jitter < 0 {
+		return errors.Errorf("netem: negative delay or jitter: %s, %s", p.Delay, p.Jitter)
+	}
+	for _, percentage := range []float32{p.Loss, p.Duplicate, p.Corrupt, p.Reorder} {
+		if percentage < 0 || percentage > 100 {
+			return errors.Errorf("netem: invalid percentage: %v", percentage)
+		}
+	}
+	if (p.Jitter != 0 || p.Reorder != 0) && p.Delay == 0 {
+		return errors.New("netem: jitter and reorder require the delay")
+	}
+	return nil
+}
+
+func (p *Params) toNetemAttrs() netlink.NetemQdiscAttrs {
+	return netlink.NetemQdiscAttrs{
+		Latency:     uint32(p.Delay.Microseconds()),
+		Jitter:      uint32(p.Jitter.Microseconds()),
+		Loss:        p.Loss,
+		Duplicate:   p.Duplicate,
+		CorruptProb: p.Corrupt,
+		ReorderProb: p.Reorder,
+	}
+}
+
+// getParams returns the netem parameters requested by the connection labels or nil
+func getParams(conn *networkservice.Connection) (*Params, error) {
+	labels := conn.GetLabels()
+	p := new(Params)
+
+	for key, duration := range map[string]*time.Duration{DelayKey: &p.Delay, JitterKey: &p.Jitter} {
+		value, ok := labels[key]
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "netem: invalid %s", key)
+		}
+		*duration = d
+	}
+
+	percentages := map[string]*float32{
+		LossKey:      &p.Loss,
+		DuplicateKey: &p.Duplicate,
+		CorruptKey:   &p.Corrupt,
+		ReorderKey:   &p.Reorder,
+	}
+	for key, percentage := range percentages {
+		value, ok := labels[key]
+		if !ok {
+			continue
+		}
+		f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "netem: invalid %s", key)
+		}
+		*percentage = float32(f)
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if p.IsZero() {
+		return nil, nil
+	}
+	return p, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package netem contains chain elements that impair the traffic of the kernel interface of the connection with the
+// netem qdisc: delay, jitter, loss, duplication, corruption and reordering. The impairment is requested by the
+// connection labels, see DelayKey, or set on the established connection with Controller. It is intended for the
+// resilience testing and replaces the root qdisc of the interface.
+package netem
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type netemServer struct {
+	controller *Controller
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that applies the netem qdisc to the kernel interface in
+// the client network namespace iff the selected mechanism for the connection is a kernel mechanism and the impairment
+// is requested by the connection labels or set with Controller. The qdisc is updated on refresh and removed on Close.
+// netem takes the root qdisc of the interface, so it fails if the interface already has the root qdisc set by the
+// other element, e.g. by shaping with the egress limit, and can't be used with the egress shaping in one chain.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &netemServer{
+		controller: newOptions(opts...).controller,
+	}
+}
+
+func (s *netemServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create(ctx, conn, s.controller); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *netemServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := del(ctx, conn, s.controller); err != nil {
+		log.FromContext(ctx).Errorf("netemServer del: %v", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
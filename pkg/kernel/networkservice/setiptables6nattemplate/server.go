@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package setiptables6nattemplate provides chain element for setup ip6tables rules template properties
+package setiptables6nattemplate
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/networkservice/connectioncontextkernel/iptables6nattemplate"
+)
+
+type setIP6TablesTemplateServer struct {
+	rulesTemplate []string
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that writes ip6tables rules template
+// to kernel mechanism
+func NewServer(rulesTemplate []string) networkservice.NetworkServiceServer {
+	return &setIP6TablesTemplateServer{
+		rulesTemplate: rulesTemplate,
+	}
+}
+
+func (s *setIP6TablesTemplateServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	mechanism := kernel.ToMechanism(request.GetConnection().GetMechanism())
+	if mechanism != nil {
+		iptables6nattemplate.SetIPTables6NatTemplate(mechanism, s.rulesTemplate...)
+	}
+
+	return next.Server(ctx).Request(ctx, request)
+}
+
+func (s *setIP6TablesTemplateServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	return next.Server(ctx).Close(ctx, conn)
+}
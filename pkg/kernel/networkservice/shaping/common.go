@@ -0,0 +1,331 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package shaping
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/redirect"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/rootqdisc"
+)
+
+const (
+	// policePriority is the tc filter priority of the police filter, it is checked before the redirect filters
+	policePriority = 0xfe
+	// latency is the maximum time a packet can wait in the tbf qdisc
+	latency = 50 * time.Millisecond
+	// htbClassMinor is the minor of the htb class shaping all the traffic
+	htbClassMinor = 1
+)
+
+type configKey struct{}
+
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, o *options) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	c, err := getConfig(conn, o)
+	if err != nil {
+		return err
+	}
+
+	applied := new(config)
+	if rawConfig, ok := metadata.Map(ctx, isClient).Load(configKey{}); ok {
+		applied = rawConfig.(*config)
+	}
+	if *c == *applied {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	ifName := link.GetInterfaceName(mechanism)
+	l, err := netlinkHandle.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "shaping: failed to find link %s", ifName)
+	}
+
+	// Store the config before applying, so Close removes the partially applied one
+	metadata.Map(ctx, isClient).Store(configKey{}, c)
+
+	if c.qdisc != applied.qdisc || c.egress != applied.egress {
+		installed := applied.egress.rate != 0
+		// The root qdisc can't be replaced with the qdisc of the other kind
+		if installed && (c.egress.rate == 0 || c.qdisc != applied.qdisc) {
+			if err := delEgress(ctx, netlinkHandle, l); err != nil {
+				return err
+			}
+			installed = false
+		}
+		if c.egress.rate != 0 {
+			// The htb qdisc can't be changed, only its class
+			replaceQdisc := c.qdisc != QdiscHTB || !installed
+			if err := setEgress(ctx, netlinkHandle, l, c, replaceQdisc); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.ingress != applied.ingress {
+		if err := delIngress(ctx, netlinkHandle, l); err != nil {
+			return err
+		}
+		if c.ingress.rate != 0 {
+			if err := setIngress(ctx, netlinkHandle, l, c.ingress); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	rawConfig, ok := metadata.Map(ctx, isClient).LoadAndDelete(configKey{})
+	if !ok {
+		return nil
+	}
+	c := rawConfig.(*config)
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	// The interface may be already deleted together with the qdiscs and filters
+	l, err := netlinkHandle.LinkByName(link.GetInterfaceName(mechanism))
+	if err != nil {
+		return nil
+	}
+
+	if c.egress.rate != 0 {
+		if err := delEgress(ctx, netlinkHandle, l); err != nil {
+			return err
+		}
+	}
+	if c.ingress.rate != 0 {
+		if err := delIngress(ctx, netlinkHandle, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setEgress(ctx context.Context, handle *netlink.Handle, l netlink.Link, c *config, replaceQdisc bool) error {
+	rate := c.egress.rate / 8
+	attrs := netlink.QdiscAttrs{
+		LinkIndex: l.Attrs().Index,
+		Handle:    netlink.MakeHandle(1, 0),
+		Parent:    netlink.HANDLE_ROOT,
+	}
+
+	var qdisc netlink.Qdisc
+	switch c.qdisc {
+	case QdiscHTB:
+		htb := netlink.NewHtb(attrs)
+		htb.Defcls = htbClassMinor
+		qdisc = htb
+	default:
+		qdisc = &netlink.Tbf{
+			QdiscAttrs: attrs,
+			Rate:       rate,
+			Limit:      uint32(min(float64(rate)*latency.Seconds()+float64(c.egress.burst), math.MaxUint32)),
+			Buffer:     netlink.Xmittime(rate, c.egress.burst),
+		}
+	}
+
+	if replaceQdisc {
+		// The root qdisc of the other element, e.g. netem, is not replaced silently. The own one may be left before
+		// the restart, so it is adopted.
+		owned, err := rootqdisc.Check(handle, l, attrs.Handle, QdiscTBF, QdiscHTB)
+		if err != nil {
+			return errors.Wrap(err, "shaping: failed to set root qdisc")
+		}
+		switch {
+		case owned != nil && owned.Type() != c.qdisc:
+			if err := delEgress(ctx, handle, l); err != nil {
+				return err
+			}
+		case owned != nil && c.qdisc == QdiscHTB:
+			replaceQdisc = false
+		}
+	}
+
+	if replaceQdisc {
+		now := time.Now()
+		if err := handle.QdiscReplace(qdisc); err != nil {
+			return errors.Wrapf(err, "shaping: failed to set %s qdisc on %s", c.qdisc, l.Attrs().Name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("qdisc", c.qdisc).
+			WithField("rate", c.egress.rate).
+			WithField("burst", c.egress.burst).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "QdiscReplace").Debug("completed")
+	}
+
+	if c.qdisc != QdiscHTB {
+		return nil
+	}
+
+	class := netlink.NewHtbClass(netlink.ClassAttrs{
+		LinkIndex: l.Attrs().Index,
+		Parent:    attrs.Handle,
+		Handle:    netlink.MakeHandle(1, htbClassMinor),
+	}, netlink.HtbClassAttrs{
+		Rate:    c.egress.rate,
+		Buffer:  c.egress.burst,
+		Cbuffer: c.egress.burst,
+	})
+	now := time.Now()
+	if err := handle.ClassReplace(class); err != nil {
+		return errors.Wrapf(err, "shaping: failed to set htb class on %s", l.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", l.Attrs().Name).
+		WithField("rate", c.egress.rate).
+		WithField("burst", c.egress.burst).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "ClassReplace").Debug("completed")
+	return nil
+}
+
+func delEgress(ctx context.Context, handle *netlink.Handle, l netlink.Link) error {
+	qdisc, err := rootqdisc.Get(handle, l)
+	if err != nil {
+		return errors.Wrap(err, "shaping: failed to delete root qdisc")
+	}
+	// The root qdisc may be already deleted or replaced by the other element
+	if qdisc == nil || qdisc.Attrs().Handle != netlink.MakeHandle(1, 0) ||
+		(qdisc.Type() != QdiscTBF && qdisc.Type() != QdiscHTB) {
+		return nil
+	}
+
+	now := time.Now()
+	if err := handle.QdiscDel(qdisc); err != nil && !errors.Is(err, unix.ENOENT) && !errors.Is(err, unix.EINVAL) {
+		return errors.Wrapf(err, "shaping: failed to delete root qdisc from %s", l.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", l.Attrs().Name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "QdiscDel").Debug("completed")
+	return nil
+}
+
+func setIngress(ctx context.Context, handle *netlink.Handle, l netlink.Link, ingress limit) error {
+	qdisc := &netlink.Clsact{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: l.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+	}
+	now := time.Now()
+	if err := handle.QdiscReplace(qdisc); err != nil {
+		return errors.Wrapf(err, "shaping: failed to add clsact qdisc to %s", l.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", l.Attrs().Name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "QdiscReplace").Debug("completed")
+
+	police := netlink.NewPoliceAction()
+	police.Rate = uint32(ingress.rate / 8)
+	police.Burst = ingress.burst
+	police.ExceedAction = netlink.TC_POLICE_SHOT
+	// Pass the conforming packets to the next filters, e.g. to the redirect ones
+	police.NotExceedAction = netlink.TC_POLICE_UNSPEC
+
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: l.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_INGRESS,
+			Priority:  policePriority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		// nil selector matches all the packets
+		Actions: []netlink.Action{police},
+	}
+	now = time.Now()
+	if err := handle.FilterAdd(filter); err != nil {
+		return errors.Wrapf(err, "shaping: failed to add police filter to %s", l.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", l.Attrs().Name).
+		WithField("rate", ingress.rate).
+		WithField("burst", ingress.burst).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "FilterAdd").Debug("completed")
+	return nil
+}
+
+func delIngress(ctx context.Context, handle *netlink.Handle, l netlink.Link) error {
+	filters, err := handle.FilterList(l, netlink.HANDLE_MIN_INGRESS)
+	// There are no filters if there is no clsact qdisc
+	if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOENT) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "shaping: failed to list filters of %s", l.Attrs().Name)
+	}
+
+	for _, filter := range filters {
+		if filter.Attrs().Priority != policePriority {
+			continue
+		}
+		now := time.Now()
+		if err := handle.FilterDel(filter); err != nil && !errors.Is(err, unix.ENOENT) {
+			return errors.Wrapf(err, "shaping: failed to delete police filter from %s", l.Attrs().Name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "FilterDel").Debug("completed")
+	}
+
+	if err := redirect.DelQdisc(ctx, handle, l); err != nil {
+		return errors.Wrap(err, "shaping: failed to delete clsact qdisc")
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package shaping
+
+type options struct {
+	qdisc   string
+	egress  limit
+	ingress limit
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithEgress limits the egress traffic of the connection interface to rate bits per second with the burst of burst
+// bytes, zero burst selects the default one. It can be overridden by the connection labels.
+func WithEgress(rate uint64, burst uint32) Option {
+	return func(o *options) {
+		o.egress = limit{rate: rate, burst: burst}
+	}
+}
+
+// WithIngress polices the ingress traffic of the connection interface to rate bits per second with the burst of
+// burst bytes, zero burst selects the default one. The exceeding packets are dropped. It can be overridden by the
+// connection labels.
+func WithIngress(rate uint64, burst uint32) Option {
+	return func(o *options) {
+		o.ingress = limit{rate: rate, burst: burst}
+	}
+}
+
+// WithHTB shapes the egress traffic with the htb qdisc instead of the tbf one. It can be overridden by the connection
+// labels.
+func WithHTB() Option {
+	return func(o *options) {
+		o.qdisc = QdiscHTB
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		qdisc: QdiscTBF,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
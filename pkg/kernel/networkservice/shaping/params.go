@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package shaping
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Connection label keys of the shaping, labels override the element options. The default burst is the amount of data
+// sent at the rate in 10ms, but not less than 1600 bytes.
+const (
+	// QdiscKey - egress qdisc: QdiscTBF (default) or QdiscHTB
+	QdiscKey = "shaping.qdisc"
+	// EgressRateKey - egress rate in the tc format: "100mbit", "10mbps", bare number is bits per second
+	EgressRateKey = "shaping.egress.rate"
+	// EgressBurstKey - egress burst in the tc format: "64kb", bare number is bytes
+	EgressBurstKey = "shaping.egress.burst"
+	// IngressRateKey - ingress police rate in the tc format: "100mbit", "10mbps", bare number is bits per second
+	IngressRateKey = "shaping.ingress.rate"
+	// IngressBurstKey - ingress police burst in the tc format: "64kb", bare number is bytes
+	IngressBurstKey = "shaping.ingress.burst"
+)
+
+// Egress qdiscs
+const (
+	QdiscTBF = "tbf"
+	QdiscHTB = "htb"
+)
+
+const (
+	// minBurst is a bit more than the Ethernet MTU, the smaller burst drops all the full size packets
+	minBurst = 1600
+	// burstHz is the inverse of the time to send the default burst at the rate
+	burstHz = 100
+)
+
+var rateUnits = map[string]float64{
+	"":     1,
+	"bit":  1,
+	"kbit": 1e3,
+	"mbit": 1e6,
+	"gbit": 1e9,
+	"tbit": 1e12,
+	"bps":  8,
+	"kbps": 8e3,
+	"mbps": 8e6,
+	"gbps": 8e9,
+	"tbps": 8e12,
+}
+
+var sizeUnits = map[string]float64{
+	"":   1,
+	"b":  1,
+	"k":  1 << 10,
+	"kb": 1 << 10,
+	"m":  1 << 20,
+	"mb": 1 << 20,
+	"g":  1 << 30,
+	"gb": 1 << 30,
+}
+
+// limit is the rate in bits per second and the burst in bytes, zero rate means no limit
+type limit struct {
+	rate  uint64
+	burst uint32
+}
+
+// config is the shaping of the connection interface
+type config struct {
+	qdisc   string
+	egress  limit
+	ingress limit
+}
+
+// getConfig returns the shaping requested for the connection by the labels and the options
+func getConfig(conn *networkservice.Connection, o *options) (*config, error) {
+	labels := conn.GetLabels()
+
+	c := &config{
+		qdisc:   o.qdisc,
+		egress:  o.egress,
+		ingress: o.ingress,
+	}
+	if value, ok := labels[QdiscKey]; ok {
+		if value != QdiscTBF && value != QdiscHTB {
+			return nil, errors.Errorf("shaping: unsupported %s: %s", QdiscKey, value)
+		}
+		c.qdisc = value
+	}
+
+	var err error
+	if c.egress, err = getLimit(labels, EgressRateKey, EgressBurstKey, c.egress); err != nil {
+		return nil, err
+	}
+	if c.ingress, err = getLimit(labels, IngressRateKey, IngressBurstKey, c.ingress); err != nil {
+		return nil, err
+	}
+	// Police action keeps the rate in 32 bits of bytes per second
+	if c.ingress.rate/8 > math.MaxUint32 {
+		return nil, errors.Errorf("shaping: too big ingress rate: %d bit", c.ingress.rate)
+	}
+	return c, nil
+}
+
+func getLimit(labels map[string]string, rateKey, burstKey string, l limit) (limit, error) {
+	if value, ok := labels[rateKey]; ok {
+		rate, err := parse(value, rateUnits, math.MaxUint64)
+		if err != nil {
+			return limit{}, errors.Wrapf(err, "shaping: invalid %s", rateKey)
+		}
+		l.rate = rate
+	}
+	if value, ok := labels[burstKey]; ok {
+		burst, err := parse(value, sizeUnits, math.MaxUint32)
+		if err != nil {
+			return limit{}, errors.Wrapf(err, "shaping: invalid %s", burstKey)
+		}
+		l.burst = uint32(burst)
+	}
+
+	if l.rate == 0 {
+		return limit{}, nil
+	}
+	if l.burst == 0 {
+		l.burst = uint32(min(max(l.rate/8/burstHz, minBurst), math.MaxUint32))
+	}
+	return l, nil
+}
+
+// parse parses the number with the optional tc unit suffix
+func parse(value string, units map[string]float64, maxValue uint64) (uint64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	i := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(value)
+	}
+
+	unit, ok := units[value[i:]]
+	if !ok {
+		return 0, errors.Errorf("unknown unit: %s", value)
+	}
+	number, err := strconv.ParseFloat(value[:i], 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid number: %s", value)
+	}
+	if number*unit > float64(maxValue) {
+		return 0, errors.Errorf("value is too big: %s", value)
+	}
+	return uint64(number * unit), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package shaping contains chain elements that limit the bandwidth of the kernel interface of the connection with
+// tbf or htb egress qdisc and ingress police filter. The limits are requested by the connection labels, see
+// EgressRateKey, or by the element options.
+package shaping
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type shapingServer struct {
+	options *options
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that shapes the egress and polices the ingress traffic
+// of the kernel interface in the client network namespace iff the selected mechanism for the connection is a kernel
+// mechanism and the limits are requested by the connection labels or the options. The limits are updated on refresh
+// and removed on Close. The egress shaping takes the root qdisc of the interface, so it fails if the interface
+// already has the root qdisc set by the other element, e.g. by netem, and can't be used with netem in one chain.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &shapingServer{
+		options: newOptions(opts...),
+	}
+}
+
+func (s *shapingServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create(ctx, conn, metadata.IsClient(s), s.options); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *shapingServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := del(ctx, conn, metadata.IsClient(s)); err != nil {
+		log.FromContext(ctx).Errorf("shapingServer del: %v", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
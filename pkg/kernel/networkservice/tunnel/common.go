@@ -0,0 +1,238 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tunnel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/peer"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/redirect"
+)
+
+const bridgeNamePrefix = "br-"
+
+type stateKey struct{}
+
+// state is the tunnel attachment of the connection stored in the metadata
+type state struct {
+	tunnelName  string
+	tunnelIndex int
+	peerName    string
+	bridgeName  string
+}
+
+// create creates the tunnel interface in the current network namespace and attaches it to the connection interface
+// stored with peer.Store on the other side of the chain
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, o *options) error {
+	p, err := getParams(conn, isClient)
+	if err != nil || p == nil {
+		return err
+	}
+
+	peerLink, ok := peer.Load(ctx, !isClient)
+	if !ok {
+		return errors.Errorf("tunnel: no kernel interface found to attach %s tunnel for the connection %s", p.mechanismType, conn.GetId())
+	}
+
+	handle, err := netlink.NewHandle()
+	if err != nil {
+		return errors.Wrap(err, "tunnel: failed to create netlink handle")
+	}
+	defer handle.Close()
+
+	s := &state{
+		tunnelName: getName(p.namePrefix(), conn),
+		peerName:   peerLink.Attrs().Name,
+	}
+	metadata.Map(ctx, isClient).Store(stateKey{}, s)
+
+	tunnel, err := ensureTunnel(ctx, handle, s.tunnelName, p)
+	if err != nil {
+		return err
+	}
+	if err := handle.LinkSetUp(tunnel); err != nil {
+		return errors.Wrapf(err, "tunnel: failed to setup link for the interface %s", s.tunnelName)
+	}
+	s.tunnelIndex = tunnel.Attrs().Index
+
+	if o.bridge {
+		s.bridgeName = getName(bridgeNamePrefix, conn)
+		return attachToBridge(ctx, handle, s.bridgeName, tunnel, peerLink)
+	}
+
+	if err := redirect.Add(ctx, handle, tunnel, peerLink); err != nil {
+		return errors.Wrap(err, "tunnel: failed to attach tunnel")
+	}
+	if err := redirect.Add(ctx, handle, peerLink, tunnel); err != nil {
+		return errors.Wrap(err, "tunnel: failed to attach tunnel")
+	}
+	return nil
+}
+
+// ensureTunnel returns the tunnel link, it is recreated if the tunnel parameters have been changed
+func ensureTunnel(ctx context.Context, handle *netlink.Handle, name string, p *params) (netlink.Link, error) {
+	expected := p.newLink(name)
+	if l, err := handle.LinkByName(name); err == nil {
+		if p.matches(l) {
+			return l, nil
+		}
+		if err := linkDel(ctx, handle, l); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err := handle.LinkAdd(expected); err != nil {
+		return nil, errors.Wrapf(err, "tunnel: failed to create %s link %s", expected.Type(), name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", name).
+		WithField("link.Type", expected.Type()).
+		WithField("vni", p.vni).
+		WithField("remote", p.remote).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkAdd").Debug("completed")
+
+	l, err := handle.LinkByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "tunnel: failed to find link %s", name)
+	}
+	return l, nil
+}
+
+// matches returns true if the existing link is the tunnel with the same parameters
+func (p *params) matches(l netlink.Link) bool {
+	if l.Attrs().MTU != p.mtu && p.mtu != 0 {
+		return false
+	}
+	switch tunnel := l.(type) {
+	case *netlink.Vxlan:
+		return p.mechanismType != GeneveMechanism && tunnel.VxlanId == int(p.vni) && tunnel.Group.Equal(p.remote) &&
+			tunnel.Port == int(p.port) && (p.local == nil || tunnel.SrcAddr.Equal(p.local))
+	case *netlink.Geneve:
+		return p.mechanismType == GeneveMechanism && tunnel.ID == p.vni && tunnel.Remote.Equal(p.remote) &&
+			tunnel.Dport == p.port
+	}
+	return false
+}
+
+func attachToBridge(ctx context.Context, handle *netlink.Handle, name string, links ...netlink.Link) error {
+	bridge, err := handle.LinkByName(name)
+	if err != nil {
+		bridge = &netlink.Bridge{LinkAttrs: netlink.LinkAttrs{Name: name}}
+
+		now := time.Now()
+		if err = handle.LinkAdd(bridge); err != nil {
+			return errors.Wrapf(err, "tunnel: failed to create bridge %s", name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkAdd").Debug("completed")
+
+		if bridge, err = handle.LinkByName(name); err != nil {
+			return errors.Wrapf(err, "tunnel: failed to find bridge %s", name)
+		}
+	}
+
+	for _, l := range links {
+		if l.Attrs().MasterIndex == bridge.Attrs().Index {
+			continue
+		}
+		now := time.Now()
+		if err := handle.LinkSetMasterByIndex(l, bridge.Attrs().Index); err != nil {
+			return errors.Wrapf(err, "tunnel: failed to attach %s to bridge %s", l.Attrs().Name, name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", l.Attrs().Name).
+			WithField("bridge", name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkSetMasterByIndex").Debug("completed")
+	}
+
+	if err := handle.LinkSetUp(bridge); err != nil {
+		return errors.Wrapf(err, "tunnel: failed to setup link for the bridge %s", name)
+	}
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	rawState, ok := metadata.Map(ctx, isClient).LoadAndDelete(stateKey{})
+	if !ok {
+		return nil
+	}
+	s := rawState.(*state)
+
+	handle, err := netlink.NewHandle()
+	if err != nil {
+		return errors.Wrap(err, "tunnel: failed to create netlink handle")
+	}
+	defer handle.Close()
+
+	// The bridge releases the attached links on delete
+	if s.bridgeName != "" {
+		if bridge, err := handle.LinkByName(s.bridgeName); err == nil {
+			if err := linkDel(ctx, handle, bridge); err != nil {
+				return err
+			}
+		}
+	} else if peerLink, err := handle.LinkByName(s.peerName); err == nil {
+		if err := redirect.Del(ctx, handle, peerLink, s.tunnelIndex); err != nil {
+			return errors.Wrap(err, "tunnel: failed to detach tunnel")
+		}
+		if err := redirect.DelQdisc(ctx, handle, peerLink); err != nil {
+			return errors.Wrap(err, "tunnel: failed to detach tunnel")
+		}
+	}
+
+	if tunnel, err := handle.LinkByName(s.tunnelName); err == nil {
+		return linkDel(ctx, handle, tunnel)
+	}
+	return nil
+}
+
+func linkDel(ctx context.Context, handle *netlink.Handle, l netlink.Link) error {
+	now := time.Now()
+	if err := handle.LinkDel(l); err != nil {
+		return errors.Wrapf(err, "tunnel: failed to delete link %s", l.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", l.Attrs().Name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
+
+// getName returns the name of the link created for the connection, it must fit in IFNAMSIZ
+func getName(prefix string, conn *networkservice.Connection) string {
+	sum := sha256.Sum256([]byte(conn.GetId()))
+	return prefix + hex.EncodeToString(sum[:])[:kernel.LinuxIfMaxLength-len(prefix)]
+}
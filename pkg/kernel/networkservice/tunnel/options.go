@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tunnel
+
+type options struct {
+	bridge bool
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithBridge attaches the tunnel interface to the connection interface through the per connection Linux bridge
+// instead of the tc redirect. It allows the kernel to learn the MAC addresses, but costs an additional bridge device
+// per connection.
+func WithBridge() Option {
+	return func(o *options) {
+		o.bridge = true
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
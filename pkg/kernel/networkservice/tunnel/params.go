@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tunnel
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/common"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/vxlan"
+)
+
+const (
+	// GeneveMechanism - Geneve remote mechanism type. It uses the same parameters as the VXLAN mechanism: src_ip,
+	// dst_ip, dst_port, vni and MTU.
+	GeneveMechanism = "GENEVE"
+
+	// DefaultVXLANPort - IANA assigned VXLAN UDP port used if the dst_port parameter is not set
+	DefaultVXLANPort = 4789
+	// DefaultGenevePort - IANA assigned Geneve UDP port used if the dst_port parameter is not set
+	DefaultGenevePort = 6081
+
+	vxlanNamePrefix  = "vx-"
+	geneveNamePrefix = "gnv-"
+)
+
+// params are the tunnel parameters from the VXLAN or Geneve mechanism
+type params struct {
+	mechanismType string
+	local         net.IP
+	remote        net.IP
+	vni           uint32
+	port          uint16
+	mtu           int
+}
+
+// getParams returns the tunnel parameters of the connection or nil if the connection mechanism is not a tunnel. The
+// client side of the connection sends packets from src_ip to dst_ip, the server side from dst_ip to src_ip.
+func getParams(conn *networkservice.Connection, isClient bool) (*params, error) {
+	mechanismType := conn.GetMechanism().GetType()
+	if mechanismType != vxlan.MECHANISM && mechanismType != GeneveMechanism {
+		return nil, nil
+	}
+	parameters := conn.GetMechanism().GetParameters()
+
+	p := &params{
+		mechanismType: mechanismType,
+		local:         net.ParseIP(parameters[common.SrcIP]),
+		remote:        net.ParseIP(parameters[common.DstIP]),
+		port:          DefaultVXLANPort,
+		mtu:           int(conn.GetContext().GetMTU()),
+	}
+	if !isClient {
+		p.local, p.remote = p.remote, p.local
+	}
+	if p.remote == nil {
+		return nil, errors.Errorf("tunnel: invalid remote IP in %s mechanism parameters: %v", mechanismType, parameters)
+	}
+	if mechanismType == GeneveMechanism {
+		p.port = DefaultGenevePort
+	}
+
+	vni, err := strconv.ParseUint(parameters[vxlan.VNI], 10, 24)
+	if err != nil {
+		return nil, errors.Wrapf(err, "tunnel: invalid VNI in %s mechanism parameters", mechanismType)
+	}
+	p.vni = uint32(vni)
+
+	if portStr, ok := parameters[common.DstPort]; ok {
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return nil, errors.Wrapf(err, "tunnel: invalid destination port in %s mechanism parameters", mechanismType)
+		}
+		p.port = uint16(port)
+	}
+
+	if mtuStr, ok := parameters[common.MTU]; ok {
+		mtu, err := strconv.ParseUint(mtuStr, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "tunnel: invalid MTU in %s mechanism parameters", mechanismType)
+		}
+		p.mtu = int(mtu)
+	}
+
+	return p, nil
+}
+
+// newLink returns the tunnel link to create. Geneve link doesn't have a local address, the kernel selects the source
+// address from the route to the remote.
+func (p *params) newLink(name string) netlink.Link {
+	attrs := netlink.LinkAttrs{
+		Name: name,
+		MTU:  p.mtu,
+	}
+	if p.mechanismType == GeneveMechanism {
+		return &netlink.Geneve{
+			LinkAttrs: attrs,
+			ID:        p.vni,
+			Remote:    p.remote,
+			Dport:     p.port,
+		}
+	}
+	return &netlink.Vxlan{
+		LinkAttrs: attrs,
+		VxlanId:   int(p.vni),
+		SrcAddr:   p.local,
+		Group:     p.remote,
+		Port:      int(p.port),
+		Learning:  true,
+	}
+}
+
+func (p *params) namePrefix() string {
+	if p.mechanismType == GeneveMechanism {
+		return geneveNamePrefix
+	}
+	return vxlanNamePrefix
+}
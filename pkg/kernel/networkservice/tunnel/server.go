@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package tunnel contains chain elements that terminate VXLAN and Geneve remote mechanisms with the kernel tunnel
+// interfaces in the forwarder network namespace and attach them to the local kernel interface of the connection
+// with tc redirect or Linux bridge
+package tunnel
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type tunnelServer struct {
+	options *options
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that creates VXLAN or Geneve tunnel interface from the
+// incoming connection mechanism parameters on Request and attaches it to the outgoing connection interface stored
+// with peer.Store on the client side of the chain, e.g. by veth.NewClient. The tunnel is deleted on Close.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &tunnelServer{
+		options: newOptions(opts...),
+	}
+}
+
+func (s *tunnelServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create(ctx, conn, metadata.IsClient(s), s.options); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *tunnelServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := del(ctx, conn, metadata.IsClient(s)); err != nil {
+		log.FromContext(ctx).Errorf("tunnelServer del: %v", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tuntap
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+)
+
+// createdKey is set in the metadata if the device has been created for the connection by this element
+type createdKey struct{}
+
+// create creates the persistent TUN/TAP device in the target network namespace and stores it in the metadata
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, o *options) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	queues := 1
+	if o.queues > 1 {
+		queues = o.queues
+	}
+
+	// The device has been already created before the restart or by someone else, it is used as is and it is not
+	// deleted by del. The number of its queues is not reported by the kernel, the multi-queue device has the maximum
+	// number of the TX queues.
+	ifName := mechanism.GetInterfaceName()
+	if l, err := netlinkHandle.LinkByName(ifName); err == nil {
+		existing, ok := l.(*netlink.Tuntap)
+		if !ok {
+			return errors.Errorf("tuntap: link %s already exists and it is not tuntap", ifName)
+		}
+		if existing.Mode != o.mode {
+			return errors.Errorf("tuntap: link %s already exists and it is %s, not %s", ifName, existing.Mode, o.mode)
+		}
+		if existing.Flags&netlink.TUNTAP_MULTI_QUEUE == 0 && existing.NumTxQueues <= 1 {
+			queues = 1
+		}
+		store(ctx, isClient, &Device{Name: ifName, Index: l.Attrs().Index, Mode: existing.Mode, Queues: queues})
+		return nil
+	}
+
+	forwarderNetNS, err := nshandle.Current()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = forwarderNetNS.Close() }()
+
+	targetNetNS, err := nshandle.FromURL(mechanism.GetNetNSURL())
+	if err != nil {
+		return errors.Wrapf(err, "tuntap: failed to get network namespace: %s", mechanism.GetNetNSURL())
+	}
+	defer func() { _ = targetNetNS.Close() }()
+
+	// The device is persistent, so the file descriptors are closed right after the creation and the workload
+	// attaches the queues by the device name
+	tuntap := &netlink.Tuntap{
+		LinkAttrs: netlink.LinkAttrs{Name: ifName},
+		Mode:      o.mode,
+		Flags:     netlink.TUNTAP_DEFAULTS,
+		Queues:    1,
+		Owner:     o.owner,
+		Group:     o.group,
+	}
+	if o.queues > 1 {
+		tuntap.Flags = netlink.TUNTAP_MULTI_QUEUE_DEFAULTS
+	}
+
+	now := time.Now()
+	// TUN/TAP device is created with ioctl, so it can't be created with the target network namespace handle
+	if err := nshandle.RunIn(forwarderNetNS, targetNetNS, func() error {
+		return netlink.LinkAdd(tuntap)
+	}); err != nil {
+		return errors.Wrapf(err, "tuntap: failed to create %s device %s", tuntap.Mode, ifName)
+	}
+	for _, fd := range tuntap.Fds {
+		_ = fd.Close()
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("mode", tuntap.Mode).
+		WithField("queues", queues).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkAdd").Debug("completed")
+	metadata.Map(ctx, isClient).Store(createdKey{}, struct{}{})
+
+	l, err := netlinkHandle.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "tuntap: failed to find link %s", ifName)
+	}
+	store(ctx, isClient, &Device{Name: ifName, Index: l.Attrs().Index, Mode: o.mode, Queues: queues})
+
+	if mtu := int(conn.GetContext().GetMTU()); mtu != 0 && mtu != l.Attrs().MTU {
+		if err := netlinkHandle.LinkSetMTU(l, mtu); err != nil {
+			return errors.Wrapf(err, "tuntap: failed to set MTU %d for %s", mtu, ifName)
+		}
+	}
+
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+	loadAndDelete(ctx, isClient)
+	if _, ok := metadata.Map(ctx, isClient).LoadAndDelete(createdKey{}); !ok {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		// The device is deleted by the kernel together with the target network namespace
+		return nil
+	}
+	defer netlinkHandle.Close()
+
+	ifName := mechanism.GetInterfaceName()
+	l, err := netlinkHandle.LinkByName(ifName)
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := netlinkHandle.LinkDel(l); err != nil {
+		return errors.Wrapf(err, "tuntap: failed to delete link %s", ifName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tuntap
+
+import (
+	"context"
+
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type deviceKey struct{}
+
+// Device is the TUN/TAP device of the connection
+type Device struct {
+	// Name - device name in the target network namespace
+	Name string
+	// Index - device ifindex in the target network namespace
+	Index int
+	// Mode - TUN or TAP
+	Mode netlink.TuntapMode
+	// Queues - number of the queues the workload can attach, 1 for the single queue device. The multi-queue device
+	// not created by this element reports the configured number of the queues.
+	Queues int
+}
+
+// Load returns the Device stored in per Connection.Id metadata, or nil if no value is present.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func Load(ctx context.Context, isClient bool) (value *Device, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(deviceKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*Device)
+	return value, ok
+}
+
+func store(ctx context.Context, isClient bool, device *Device) {
+	metadata.Map(ctx, isClient).Store(deviceKey{}, device)
+}
+
+func loadAndDelete(ctx context.Context, isClient bool) (value *Device, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(deviceKey{})
+	if !ok {
+		return
+	}
+	value, ok = rawValue.(*Device)
+	return value, ok
+}
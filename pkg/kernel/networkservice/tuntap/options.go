@@ -0,0 +1,64 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package tuntap
+
+import "github.com/vishvananda/netlink"
+
+type options struct {
+	mode   netlink.TuntapMode
+	queues int
+	owner  uint32
+	group  uint32
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithTUN creates TUN (layer 3) device instead of the default TAP (layer 2) device
+func WithTUN() Option {
+	return func(o *options) {
+		o.mode = netlink.TUNTAP_MODE_TUN
+	}
+}
+
+// WithMultiQueue creates multi-queue device, the workload can attach up to the given number of queues
+func WithMultiQueue(queues int) Option {
+	return func(o *options) {
+		o.queues = queues
+	}
+}
+
+// WithOwner sets the user and the group allowed to attach to the device, root by default
+func WithOwner(uid, gid uint32) Option {
+	return func(o *options) {
+		o.owner = uid
+		o.group = gid
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		mode: netlink.TUNTAP_MODE_TAP,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
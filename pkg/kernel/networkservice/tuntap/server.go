@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package tuntap contains chain elements that create persistent TUN/TAP devices for the userspace workloads in the
+// network namespace of the kernel mechanism
+package tuntap
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type tuntapServer struct {
+	options *options
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that creates persistent TAP (default) or TUN device
+// in the client network namespace on Request and deletes it on Close. The device is named from the kernel mechanism
+// interface name and stored in the metadata, see Load. It should be placed before connectioncontextkernel in the
+// chain, so the device exists when the connection context is applied.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &tuntapServer{
+		options: newOptions(opts...),
+	}
+}
+
+func (s *tuntapServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	_, isEstablished := Load(ctx, metadata.IsClient(s))
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	if !isEstablished {
+		if err := create(ctx, request.GetConnection(), metadata.IsClient(s), s.options); err != nil {
+			if delErr := del(ctx, request.GetConnection(), metadata.IsClient(s)); delErr != nil {
+				err = errors.Wrapf(err, "failed to delete device: %s", delErr.Error())
+			}
+			return nil, err
+		}
+	}
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !isEstablished {
+		delCtx, cancelDel := postponeCtxFunc()
+		defer cancelDel()
+
+		if delErr := del(delCtx, request.GetConnection(), metadata.IsClient(s)); delErr != nil {
+			err = errors.Wrapf(err, "server request failed, failed to delete device: %s", delErr.Error())
+		}
+	}
+
+	return conn, err
+}
+
+func (s *tuntapServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	// The other elements should clean up the interface before it is deleted
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if delErr := del(ctx, conn, metadata.IsClient(s)); delErr != nil {
+		log.FromContext(ctx).Errorf("tuntapServer del: %v", delErr.Error())
+	}
+
+	return rv, err
+}
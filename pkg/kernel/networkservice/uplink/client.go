@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package uplink
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type uplinkClient struct {
+	parent  string
+	options *options
+}
+
+// NewClient - returns a new networkservice.NetworkServiceClient that creates macvlan (default) or ipvlan
+// sub-interface of the parent interface in the endpoint network namespace on Request and deletes it on Close. The
+// sub-interface is named from the kernel mechanism interface name. It should be placed after connectioncontextkernel
+// in the chain, so the interface exists when the connection context is applied.
+func NewClient(parent string, opts ...Option) networkservice.NetworkServiceClient {
+	return &uplinkClient{
+		parent:  parent,
+		options: newOptions(opts...),
+	}
+}
+
+func (c *uplinkClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	_, isEstablished := metadata.Map(ctx, metadata.IsClient(c)).Load(createdKey{})
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isEstablished {
+		if err := create(ctx, conn, metadata.IsClient(c), c.parent, c.options); err != nil {
+			closeCtx, cancelClose := postponeCtxFunc()
+			defer cancelClose()
+
+			if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+				err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+			}
+
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *uplinkClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if err := del(ctx, conn, metadata.IsClient(c)); err != nil {
+		log.FromContext(ctx).Errorf("uplinkClient del: %v", err.Error())
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package uplink
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	link "github.com/networkservicemesh/sdk-kernel/pkg/kernel"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+)
+
+const aliasPrefix = "nsm-"
+
+// createdKey is set in the metadata if the sub-interface has been created for the connection by this element
+type createdKey struct{}
+
+// create creates the sub-interface of the parent directly in the target network namespace. The sub-interface is
+// marked with the alias derived from the connection ID, so the one created before the restart is adopted and the
+// other existing link with the same name is not taken over.
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool, parentName string, o *options) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		return err
+	}
+	defer netlinkHandle.Close()
+
+	// The sub-interface has been already created before the restart or by someone else
+	ifName := mechanism.GetInterfaceName()
+	alias := getAlias(conn)
+	if l, err := netlinkHandle.LinkByName(ifName); err == nil {
+		if l.Attrs().Alias != alias {
+			return errors.Errorf("uplink: link %s already exists and it is not created for the connection", ifName)
+		}
+		if expected := o.newLink(netlink.LinkAttrs{}).Type(); l.Type() != expected {
+			return errors.Errorf("uplink: link %s already exists and it is not %s", ifName, expected)
+		}
+		metadata.Map(ctx, isClient).Store(createdKey{}, struct{}{})
+		return nil
+	}
+
+	parent, err := netlink.LinkByName(parentName)
+	if err != nil {
+		return errors.Wrapf(err, "uplink: failed to find parent link %s", parentName)
+	}
+
+	targetNetNS, err := nshandle.FromURL(mechanism.GetNetNSURL())
+	if err != nil {
+		return errors.Wrapf(err, "uplink: failed to get network namespace: %s", mechanism.GetNetNSURL())
+	}
+	defer func() { _ = targetNetNS.Close() }()
+
+	l := o.newLink(netlink.LinkAttrs{
+		Name:        ifName,
+		ParentIndex: parent.Attrs().Index,
+		MTU:         int(conn.GetContext().GetMTU()),
+		Namespace:   netlink.NsFd(int(targetNetNS)),
+	})
+
+	now := time.Now()
+	if err := netlink.LinkAdd(l); err != nil {
+		return errors.Wrapf(err, "uplink: failed to create %s sub-interface %s of %s", l.Type(), ifName, parentName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("link.Type", l.Type()).
+		WithField("parent", parentName).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkAdd").Debug("completed")
+
+	// The alias is not set by the kernel on the link creation
+	if err := setAlias(ctx, netlinkHandle, ifName, alias); err != nil {
+		if l, findErr := netlinkHandle.LinkByName(ifName); findErr == nil {
+			_ = netlinkHandle.LinkDel(l)
+		}
+		return err
+	}
+	metadata.Map(ctx, isClient).Store(createdKey{}, struct{}{})
+
+	return nil
+}
+
+func setAlias(ctx context.Context, handle *netlink.Handle, ifName, alias string) error {
+	l, err := handle.LinkByName(ifName)
+	if err != nil {
+		return errors.Wrapf(err, "uplink: failed to find link %s", ifName)
+	}
+
+	now := time.Now()
+	if err := handle.LinkSetAlias(l, alias); err != nil {
+		return errors.Wrapf(err, "uplink: failed to set alias %s on link %s", alias, ifName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("link.Alias", alias).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkSetAlias").Debug("completed")
+	return nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+	metadata.Map(ctx, isClient).Delete(createdKey{})
+
+	netlinkHandle, err := link.GetNetlinkHandle(mechanism.GetNetNSURL())
+	if err != nil {
+		// The sub-interface is deleted by the kernel together with the target network namespace
+		return nil
+	}
+	defer netlinkHandle.Close()
+
+	ifName := mechanism.GetInterfaceName()
+	l, err := netlinkHandle.LinkByName(ifName)
+	// The link of the other connection or of someone else is not deleted
+	if err != nil || l.Attrs().Alias != getAlias(conn) {
+		return nil
+	}
+
+	now := time.Now()
+	if err := netlinkHandle.LinkDel(l); err != nil {
+		return errors.Wrapf(err, "uplink: failed to delete link %s", ifName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
+
+// getAlias returns the alias marking the sub-interface created for the connection, it must fit in IFALIASZ
+func getAlias(conn *networkservice.Connection) string {
+	sum := sha256.Sum256([]byte(conn.GetId()))
+	return aliasPrefix + hex.EncodeToString(sum[:])
+}
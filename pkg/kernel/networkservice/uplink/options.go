@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package uplink
+
+import "github.com/vishvananda/netlink"
+
+type options struct {
+	newLink func(attrs netlink.LinkAttrs) netlink.Link
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithMacvlan creates macvlan sub-interface in the given mode: bridge, private, vepa or passthru. It is the default
+// with bridge mode.
+func WithMacvlan(mode netlink.MacvlanMode) Option {
+	return func(o *options) {
+		o.newLink = func(attrs netlink.LinkAttrs) netlink.Link {
+			return &netlink.Macvlan{LinkAttrs: attrs, Mode: mode}
+		}
+	}
+}
+
+// WithIPVlan creates ipvlan sub-interface in the given mode: l2, l3 or l3s
+func WithIPVlan(mode netlink.IPVlanMode) Option {
+	return func(o *options) {
+		o.newLink = func(attrs netlink.LinkAttrs) netlink.Link {
+			return &netlink.IPVlan{LinkAttrs: attrs, Mode: mode}
+		}
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := new(options)
+	WithMacvlan(netlink.MACVLAN_MODE_BRIDGE)(o)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
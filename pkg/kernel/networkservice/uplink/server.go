@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package uplink contains chain element that creates macvlan or ipvlan sub-interface of the host uplink interface
+// directly in the network namespace of the kernel mechanism
+package uplink
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type uplinkServer struct {
+	parent  string
+	options *options
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that creates macvlan (default) or ipvlan
+// sub-interface of the parent interface in the client network namespace on Request and deletes it on Close. The
+// sub-interface is named from the kernel mechanism interface name. It should be placed before
+// connectioncontextkernel in the chain, so the interface exists when the connection context is applied.
+func NewServer(parent string, opts ...Option) networkservice.NetworkServiceServer {
+	return &uplinkServer{
+		parent:  parent,
+		options: newOptions(opts...),
+	}
+}
+
+func (s *uplinkServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	_, isEstablished := metadata.Map(ctx, metadata.IsClient(s)).Load(createdKey{})
+
+	if !isEstablished {
+		if err := create(ctx, request.GetConnection(), metadata.IsClient(s), s.parent, s.options); err != nil {
+			return nil, err
+		}
+	}
+
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil && !isEstablished {
+		delCtx, cancelDel := postponeCtxFunc()
+		defer cancelDel()
+
+		if delErr := del(delCtx, request.GetConnection(), metadata.IsClient(s)); delErr != nil {
+			err = errors.Wrapf(err, "server request failed, failed to delete sub-interface: %s", delErr.Error())
+		}
+	}
+
+	return conn, err
+}
+
+func (s *uplinkServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	// The other elements should clean up the interface before it is deleted
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if delErr := del(ctx, conn, metadata.IsClient(s)); delErr != nil {
+		log.FromContext(ctx).Errorf("uplinkServer del: %v", delErr.Error())
+	}
+
+	return rv, err
+}
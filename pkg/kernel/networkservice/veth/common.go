@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package veth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/peer"
+)
+
+const peerNamePrefix = "nsm-"
+
+// create creates the veth pair with one end in the target network namespace and the peer in the current one and
+// returns true if the pair has been created
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool) (bool, error) {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return false, nil
+	}
+
+	// The pair has been already created on the previous Request or before the restart
+	peerName := getPeerName(conn)
+	if peerLink, err := netlink.LinkByName(peerName); err == nil {
+		peer.Store(ctx, isClient, peerLink)
+		return false, nil
+	}
+
+	targetNetNS, err := nshandle.FromURL(mechanism.GetNetNSURL())
+	if err != nil {
+		return false, errors.Wrapf(err, "veth: failed to get network namespace: %s", mechanism.GetNetNSURL())
+	}
+	defer func() { _ = targetNetNS.Close() }()
+
+	ifName := mechanism.GetInterfaceName()
+	veth := &netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{
+			Name:      ifName,
+			MTU:       int(conn.GetContext().GetMTU()),
+			Namespace: netlink.NsFd(int(targetNetNS)),
+		},
+		PeerName: peerName,
+	}
+
+	now := time.Now()
+	if err = netlink.LinkAdd(veth); err != nil {
+		return false, errors.Wrapf(err, "veth: failed to create veth pair %s - %s", ifName, peerName)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", ifName).
+		WithField("peer.Name", peerName).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkAdd").Debug("completed")
+
+	peerLink, err := netlink.LinkByName(peerName)
+	if err != nil {
+		return true, errors.Wrapf(err, "veth: failed to find link %s", peerName)
+	}
+	if err = netlink.LinkSetUp(peerLink); err != nil {
+		return true, errors.Wrapf(err, "veth: failed to setup link for the interface %v", peerLink)
+	}
+	peer.Store(ctx, isClient, peerLink)
+
+	return true, nil
+}
+
+func del(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	if kernel.ToMechanism(conn.GetMechanism()) == nil {
+		return nil
+	}
+	peer.Delete(ctx, isClient)
+
+	peerName := getPeerName(conn)
+	peerLink, err := netlink.LinkByName(peerName)
+	if err != nil {
+		// The pair is deleted by the kernel together with the target network namespace
+		return nil
+	}
+
+	now := time.Now()
+	if err := netlink.LinkDel(peerLink); err != nil {
+		return errors.Wrapf(err, "veth: failed to delete link %s", peerName)
+	}
+	log.FromContext(ctx).
+		WithField("peer.Name", peerName).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
+
+// getPeerName returns the name of the peer link for the connection, it must fit in IFNAMSIZ
+func getPeerName(conn *networkservice.Connection) string {
+	sum := sha256.Sum256([]byte(conn.GetId()))
+	return peerNamePrefix + hex.EncodeToString(sum[:])[:kernel.LinuxIfMaxLength-len(peerNamePrefix)]
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package wireguard
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type wireguardClient struct {
+	options *options
+}
+
+// NewClient - returns a new networkservice.NetworkServiceClient that creates WireGuard interface for the WIREGUARD
+// mechanism in the current network namespace. The local public key, listen port and endpoint IP are sent to the
+// server in the mechanism preferences, the server public key and endpoint are configured as the interface peer
+// when the connection is established. The peer allowed IPs are the destination addresses and routes of the
+// connection IP context. The interface is deleted on Close.
+func NewClient(opts ...Option) networkservice.NetworkServiceClient {
+	return &wireguardClient{
+		options: newOptions(opts...),
+	}
+}
+
+func (c *wireguardClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	connID := request.GetConnection().GetId()
+	mechanisms := append([]*networkservice.Mechanism{request.GetConnection().GetMechanism()}, request.GetMechanismPreferences()...)
+
+	// The interface of the established connection is kept on failure
+	var d *device
+	var created bool
+	for _, m := range mechanisms {
+		mechanism := wireguard.ToMechanism(m)
+		if mechanism == nil {
+			continue
+		}
+		if d == nil {
+			var err error
+			if d, created, err = ensureDevice(ctx, connID, c.options); err != nil {
+				if !created {
+					return nil, err
+				}
+				if delErr := del(ctx, connID); delErr != nil {
+					err = errors.Wrapf(err, "failed to delete WireGuard interface: %s", delErr.Error())
+				}
+				return nil, err
+			}
+		}
+		setParameters(mechanism, metadata.IsClient(c), d, c.options)
+	}
+
+	conn, err := next.Client(ctx).Request(ctx, request, opts...)
+	if err != nil {
+		if created {
+			delCtx, cancelDel := postponeCtxFunc()
+			defer cancelDel()
+
+			if delErr := del(delCtx, connID); delErr != nil {
+				err = errors.Wrapf(err, "client request failed, failed to delete WireGuard interface: %s", delErr.Error())
+			}
+		}
+		return nil, err
+	}
+
+	// Other mechanism has been selected
+	if wireguard.ToMechanism(conn.GetMechanism()) == nil {
+		if d != nil {
+			if err := del(ctx, connID); err != nil {
+				log.FromContext(ctx).Errorf("wireguardClient del: %v", err.Error())
+			}
+		}
+		return conn, nil
+	}
+
+	if err := configurePeer(ctx, conn, metadata.IsClient(c), d, c.options); err != nil {
+		if !created {
+			return nil, err
+		}
+
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := c.Close(closeCtx, conn, opts...); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c *wireguardClient) Close(ctx context.Context, conn *networkservice.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if wireguard.ToMechanism(conn.GetMechanism()) != nil {
+		if err := del(ctx, conn.GetId()); err != nil {
+			log.FromContext(ctx).Errorf("wireguardClient del: %v", err.Error())
+		}
+	}
+	return next.Client(ctx).Close(ctx, conn, opts...)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package wireguard
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+const namePrefix = "wg-"
+
+// device is the local WireGuard interface of the connection
+type device struct {
+	name       string
+	publicKey  wgtypes.Key
+	listenPort int
+}
+
+// ensureDevice creates the WireGuard interface of the connection in the current network namespace if it doesn't
+// exist yet and sets its private key. The listen port is selected by the kernel. created is true if the interface
+// has been created by this call, even if the following configuration failed.
+func ensureDevice(ctx context.Context, connID string, o *options) (d *device, created bool, err error) {
+	name := getName(connID)
+	if _, err := netlink.LinkByName(name); err != nil {
+		l := &netlink.Wireguard{LinkAttrs: netlink.LinkAttrs{Name: name}}
+
+		now := time.Now()
+		if err := netlink.LinkAdd(l); err != nil {
+			return nil, false, errors.Wrapf(err, "wireguard: failed to create link %s", name)
+		}
+		log.FromContext(ctx).
+			WithField("link.Name", name).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "LinkAdd").Debug("completed")
+		created = true
+	}
+
+	d, err = configureDevice(name, o)
+	return d, created, err
+}
+
+func configureDevice(name string, o *options) (*device, error) {
+	client, err := wgctrl.New()
+	if err != nil {
+		return nil, errors.Wrap(err, "wireguard: failed to open WireGuard control client")
+	}
+	defer func() { _ = client.Close() }()
+
+	wgDevice, err := client.Device(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "wireguard: failed to get device %s", name)
+	}
+
+	// The device keeps the private key between the refreshes and the restarts
+	privateKey := wgDevice.PrivateKey
+	if o.privateKey != nil {
+		privateKey = *o.privateKey
+	}
+	if privateKey == (wgtypes.Key{}) {
+		if privateKey, err = wgtypes.GeneratePrivateKey(); err != nil {
+			return nil, errors.Wrap(err, "wireguard: failed to generate private key")
+		}
+	}
+	if privateKey != wgDevice.PrivateKey {
+		if err := client.ConfigureDevice(name, wgtypes.Config{PrivateKey: &privateKey}); err != nil {
+			return nil, errors.Wrapf(err, "wireguard: failed to set private key of %s", name)
+		}
+	}
+
+	l, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "wireguard: failed to find link %s", name)
+	}
+	if err := netlink.LinkSetUp(l); err != nil {
+		return nil, errors.Wrapf(err, "wireguard: failed to setup link for the interface %s", name)
+	}
+
+	// The listen port is known after the device is up
+	if wgDevice, err = client.Device(name); err != nil {
+		return nil, errors.Wrapf(err, "wireguard: failed to get device %s", name)
+	}
+
+	return &device{
+		name:       name,
+		publicKey:  privateKey.PublicKey(),
+		listenPort: wgDevice.ListenPort,
+	}, nil
+}
+
+// configurePeer sets the other side of the connection as the only peer of the device
+func configurePeer(ctx context.Context, conn *networkservice.Connection, isClient bool, d *device, o *options) error {
+	mechanism := wireguard.ToMechanism(conn.GetMechanism())
+
+	publicKeyStr, endpointIP, endpointPort := mechanism.DstPublicKey(), mechanism.DstIP(), mechanism.DstPort()
+	if !isClient {
+		publicKeyStr, endpointIP, endpointPort = mechanism.SrcPublicKey(), mechanism.SrcIP(), mechanism.SrcPort()
+	}
+	publicKey, err := wgtypes.ParseKey(publicKeyStr)
+	if err != nil {
+		return errors.Wrapf(err, "wireguard: invalid peer public key: %s", publicKeyStr)
+	}
+
+	peerConfig := wgtypes.PeerConfig{
+		PublicKey:                   publicKey,
+		ReplaceAllowedIPs:           true,
+		AllowedIPs:                  getAllowedIPs(conn, isClient),
+		PersistentKeepaliveInterval: o.keepaliveInterval,
+	}
+	// The server side learns the endpoint from the first handshake if the client endpoint is unknown
+	if endpointIP != nil && endpointPort != 0 {
+		peerConfig.Endpoint = &net.UDPAddr{IP: endpointIP, Port: int(endpointPort)}
+	}
+
+	client, err := wgctrl.New()
+	if err != nil {
+		return errors.Wrap(err, "wireguard: failed to open WireGuard control client")
+	}
+	defer func() { _ = client.Close() }()
+
+	now := time.Now()
+	if err := client.ConfigureDevice(d.name, wgtypes.Config{
+		ReplacePeers: true,
+		Peers:        []wgtypes.PeerConfig{peerConfig},
+	}); err != nil {
+		return errors.Wrapf(err, "wireguard: failed to configure peer %s of %s", publicKeyStr, d.name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", d.name).
+		WithField("peer", publicKeyStr).
+		WithField("endpoint", peerConfig.Endpoint).
+		WithField("allowedIPs", peerConfig.AllowedIPs).
+		WithField("duration", time.Since(now)).
+		WithField("wgctrl", "ConfigureDevice").Debug("completed")
+
+	return nil
+}
+
+// setParameters sets the local public key, port and IP in the mechanism parameters for the other side
+func setParameters(mechanism *wireguard.Mechanism, isClient bool, d *device, o *options) {
+	if isClient {
+		mechanism.SetSrcPublicKey(d.publicKey.String())
+		mechanism.SetSrcPort(uint16(d.listenPort))
+		if o.endpointIP != nil {
+			mechanism.SetSrcIP(o.endpointIP)
+		}
+		return
+	}
+	mechanism.SetDstPublicKey(d.publicKey.String())
+	mechanism.SetDstPort(uint16(d.listenPort))
+	if o.endpointIP != nil {
+		mechanism.SetDstIP(o.endpointIP)
+	}
+}
+
+// getAllowedIPs returns the addresses and routes of the other side of the connection
+func getAllowedIPs(conn *networkservice.Connection, isClient bool) []net.IPNet {
+	ipContext := conn.GetContext().GetIpContext()
+
+	ipNets, routes := ipContext.GetSrcIPNets(), ipContext.GetSrcRoutes()
+	if isClient {
+		ipNets, routes = ipContext.GetDstIPNets(), ipContext.GetDstRoutes()
+	}
+	for _, route := range routes {
+		ipNets = append(ipNets, route.GetPrefixIPNet())
+	}
+
+	var allowedIPs []net.IPNet
+	for _, ipNet := range ipNets {
+		if ipNet != nil {
+			allowedIPs = append(allowedIPs, *ipNet)
+		}
+	}
+	return allowedIPs
+}
+
+func del(ctx context.Context, connID string) error {
+	name := getName(connID)
+	l, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := netlink.LinkDel(l); err != nil {
+		return errors.Wrapf(err, "wireguard: failed to delete link %s", name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "LinkDel").Debug("completed")
+	return nil
+}
+
+// getName returns the name of the WireGuard interface for the connection, it must fit in IFNAMSIZ
+func getName(connID string) string {
+	sum := sha256.Sum256([]byte(connID))
+	return namePrefix + hex.EncodeToString(sum[:])[:kernel.LinuxIfMaxLength-len(namePrefix)]
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package wireguard
+
+import (
+	"net"
+	"time"
+
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+type options struct {
+	privateKey        *wgtypes.Key
+	endpointIP        net.IP
+	keepaliveInterval *time.Duration
+}
+
+// Option is an option pattern for NewClient, NewServer
+type Option func(o *options)
+
+// WithPrivateKey sets the private key used for all the connections. By default the private key is generated for
+// each connection on the first Request and kept in the interface until Close.
+func WithPrivateKey(key wgtypes.Key) Option {
+	return func(o *options) {
+		o.privateKey = &key
+	}
+}
+
+// WithEndpointIP sets the local IP address sent to the other side in the mechanism parameters as the peer endpoint.
+// It is required if the IP address is not set by the other chain elements.
+func WithEndpointIP(ip net.IP) Option {
+	return func(o *options) {
+		o.endpointIP = ip
+	}
+}
+
+// WithPersistentKeepalive sets the interval of the keepalive packets sent to the peer, it keeps the NAT mappings
+// alive. Zero interval disables it.
+func WithPersistentKeepalive(interval time.Duration) Option {
+	return func(o *options) {
+		o.keepaliveInterval = &interval
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package wireguard contains chain elements that set up encrypted connections with the kernel WireGuard interfaces
+// for the WIREGUARD mechanism. The client and server exchange the public keys and the endpoints through the
+// mechanism parameters.
+package wireguard
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/wireguard"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type wireguardServer struct {
+	options *options
+}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that creates WireGuard interface for the WIREGUARD
+// mechanism in the current network namespace. The client public key and endpoint from the mechanism parameters are
+// configured as the interface peer, the local public key, listen port and endpoint IP are sent back to the client.
+// The peer allowed IPs are the source addresses and routes of the connection IP context. The interface is deleted
+// on Close.
+func NewServer(opts ...Option) networkservice.NetworkServiceServer {
+	return &wireguardServer{
+		options: newOptions(opts...),
+	}
+}
+
+func (s *wireguardServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn := request.GetConnection()
+	mechanism := wireguard.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return next.Server(ctx).Request(ctx, request)
+	}
+
+	// The interface of the established connection is kept on failure
+	created, err := s.create(ctx, conn, mechanism)
+	if err != nil {
+		if !created {
+			return nil, err
+		}
+		if delErr := del(ctx, conn.GetId()); delErr != nil {
+			err = errors.Wrapf(err, "failed to delete WireGuard interface: %s", delErr.Error())
+		}
+		return nil, err
+	}
+
+	conn, err = next.Server(ctx).Request(ctx, request)
+	if err != nil && created {
+		delCtx, cancelDel := postponeCtxFunc()
+		defer cancelDel()
+
+		if delErr := del(delCtx, request.GetConnection().GetId()); delErr != nil {
+			err = errors.Wrapf(err, "server request failed, failed to delete WireGuard interface: %s", delErr.Error())
+		}
+	}
+
+	return conn, err
+}
+
+func (s *wireguardServer) create(ctx context.Context, conn *networkservice.Connection, mechanism *wireguard.Mechanism) (created bool, err error) {
+	d, created, err := ensureDevice(ctx, conn.GetId(), s.options)
+	if err != nil {
+		return created, err
+	}
+	setParameters(mechanism, metadata.IsClient(s), d, s.options)
+
+	return created, configurePeer(ctx, conn, metadata.IsClient(s), d, s.options)
+}
+
+func (s *wireguardServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	rv, err := next.Server(ctx).Close(ctx, conn)
+
+	if wireguard.ToMechanism(conn.GetMechanism()) != nil {
+		if delErr := del(ctx, conn.GetId()); delErr != nil {
+			log.FromContext(ctx).Errorf("wireguardServer del: %v", delErr.Error())
+		}
+	}
+
+	return rv, err
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package xconnect
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/peer"
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/redirect"
+)
+
+type stateKey struct{}
+
+// state is the cross-connected interfaces of the connection stored in the metadata
+type state struct {
+	clientName  string
+	clientIndex int
+	serverName  string
+	serverIndex int
+}
+
+// create redirects the packets between the client and the server side interfaces of the connection stored with
+// peer.Store in both directions
+func create(ctx context.Context, conn *networkservice.Connection, isClient bool) error {
+	clientLink, ok := peer.Load(ctx, true)
+	if !ok {
+		return errors.Errorf("xconnect: no client side interface found for the connection %s", conn.GetId())
+	}
+	serverLink, ok := peer.Load(ctx, false)
+	if !ok {
+		return errors.Errorf("xconnect: no server side interface found for the connection %s", conn.GetId())
+	}
+
+	s := &state{
+		clientName: clientLink.Attrs().Name,
+		serverName: serverLink.Attrs().Name,
+	}
+	metadata.Map(ctx, isClient).Store(stateKey{}, s)
+
+	handle, err := netlink.NewHandle()
+	if err != nil {
+		return errors.Wrap(err, "xconnect: failed to create netlink handle")
+	}
+	defer handle.Close()
+
+	// The stored link attributes may be outdated
+	if clientLink, err = handle.LinkByName(s.clientName); err != nil {
+		return errors.Wrapf(err, "xconnect: failed to find link %s", s.clientName)
+	}
+	if serverLink, err = handle.LinkByName(s.serverName); err != nil {
+		return errors.Wrapf(err, "xconnect: failed to find link %s", s.serverName)
+	}
+	s.clientIndex, s.serverIndex = clientLink.Attrs().Index, serverLink.Attrs().Index
+
+	if err := redirect.Add(ctx, handle, clientLink, serverLink); err != nil {
+		return errors.Wrap(err, "xconnect: failed to cross-connect interfaces")
+	}
+	if err := redirect.Add(ctx, handle, serverLink, clientLink); err != nil {
+		return errors.Wrap(err, "xconnect: failed to cross-connect interfaces")
+	}
+	return nil
+}
+
+func del(ctx context.Context, isClient bool) error {
+	rawState, ok := metadata.Map(ctx, isClient).LoadAndDelete(stateKey{})
+	if !ok {
+		return nil
+	}
+	s := rawState.(*state)
+
+	handle, err := netlink.NewHandle()
+	if err != nil {
+		return errors.Wrap(err, "xconnect: failed to create netlink handle")
+	}
+	defer handle.Close()
+
+	// The interfaces may be already deleted together with the connections
+	for name, toIndex := range map[string]int{s.clientName: s.serverIndex, s.serverName: s.clientIndex} {
+		l, err := handle.LinkByName(name)
+		if err != nil {
+			continue
+		}
+		if err := redirect.Del(ctx, handle, l, toIndex); err != nil {
+			return errors.Wrap(err, "xconnect: failed to remove cross-connect")
+		}
+		if err := redirect.DelQdisc(ctx, handle, l); err != nil {
+			return errors.Wrap(err, "xconnect: failed to remove cross-connect")
+		}
+	}
+	return nil
+}
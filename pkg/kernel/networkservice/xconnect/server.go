@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package xconnect contains chain elements that cross-connect the client and server side interfaces of the connection
+// in the forwarder network namespace with clsact qdiscs and tc mirred redirect filters
+package xconnect
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/core/next"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+	"github.com/networkservicemesh/sdk/pkg/tools/postpone"
+)
+
+type xconnectServer struct{}
+
+// NewServer - returns a new networkservice.NetworkServiceServer that redirects all the packets between the server
+// side interface of the connection and the client side interface, both stored with peer.Store by the next elements,
+// e.g. by veth.NewServer and veth.NewClient. The frames are passed unchanged, so the interfaces should carry Ethernet
+// payload or have the neighbor entries with the MAC addresses of the other side. The redirects are removed on Close.
+func NewServer() networkservice.NetworkServiceServer {
+	return &xconnectServer{}
+}
+
+func (s *xconnectServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*networkservice.Connection, error) {
+	postponeCtxFunc := postpone.ContextWithValues(ctx)
+
+	conn, err := next.Server(ctx).Request(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := create(ctx, conn, metadata.IsClient(s)); err != nil {
+		closeCtx, cancelClose := postponeCtxFunc()
+		defer cancelClose()
+
+		if _, closeErr := s.Close(closeCtx, conn); closeErr != nil {
+			err = errors.Wrapf(err, "connection closed with error: %s", closeErr.Error())
+		}
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (s *xconnectServer) Close(ctx context.Context, conn *networkservice.Connection) (*empty.Empty, error) {
+	if err := del(ctx, metadata.IsClient(s)); err != nil {
+		log.FromContext(ctx).Errorf("xconnectServer del: %v", err.Error())
+	}
+	return next.Server(ctx).Close(ctx, conn)
+}
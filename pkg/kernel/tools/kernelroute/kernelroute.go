@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package kernelroute provides the helpers installing the connection routes into the kernel
+package kernelroute
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// FromRoute returns the kernel route to the route prefix through the link in the table. The route with the next hop
+// outside the link scope is installed with the onlink flag, so the next hop doesn't need to be reachable.
+// tableID = 0 is the main table.
+func FromRoute(l netlink.Link, tableID int, scope netlink.Scope, route *networkservice.Route) (*netlink.Route, error) {
+	if route.GetPrefixIPNet() == nil {
+		return nil, errors.New("kernelRoute prefix must not be nil")
+	}
+	dst := route.GetPrefixIPNet()
+	dst.IP = dst.IP.Mask(dst.Mask)
+	kernelRoute := &netlink.Route{
+		LinkIndex: l.Attrs().Index,
+		Table:     tableID,
+		Scope:     scope,
+		Dst:       dst,
+	}
+	gw := route.GetNextHopIP()
+	if gw != nil {
+		kernelRoute.Gw = gw
+		if scope != netlink.SCOPE_LINK {
+			kernelRoute.SetFlag(netlink.FLAG_ONLINK)
+		}
+	}
+	return kernelRoute, nil
+}
+
+// Replace adds the route or replaces the existing one with the same destination and metric
+func Replace(ctx context.Context, handle *netlink.Handle, linkName string, kernelRoute *netlink.Route) error {
+	now := time.Now()
+	if err := handle.RouteReplace(kernelRoute); err != nil {
+		withFields(ctx, linkName, kernelRoute).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteReplace").Errorf("error %+v", err)
+		return errors.Wrap(err, "failed to add route")
+	}
+	withFields(ctx, linkName, kernelRoute).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "RouteReplace").Debug("completed")
+	return nil
+}
+
+// Del deletes the route, the route already deleted by the kernel together with the interface is not an error
+func Del(ctx context.Context, handle *netlink.Handle, linkName string, kernelRoute *netlink.Route) error {
+	now := time.Now()
+	if err := handle.RouteDel(kernelRoute); err != nil && !errors.Is(err, unix.ESRCH) && !errors.Is(err, unix.ENODEV) {
+		withFields(ctx, linkName, kernelRoute).
+			WithField("duration", time.Since(now)).
+			WithField("netlink", "RouteDel").Errorf("error %+v", err)
+		return errors.Wrap(err, "failed to delete route")
+	}
+	withFields(ctx, linkName, kernelRoute).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "RouteDel").Debug("completed")
+	return nil
+}
+
+func withFields(ctx context.Context, linkName string, kernelRoute *netlink.Route) log.Logger {
+	logger := log.FromContext(ctx).
+		WithField("link.Name", linkName).
+		WithField("Dst", kernelRoute.Dst).
+		WithField("Gw", kernelRoute.Gw).
+		WithField("Scope", kernelRoute.Scope).
+		WithField("Flags", kernelRoute.Flags).
+		WithField("Table", kernelRoute.Table)
+	if kernelRoute.Encap != nil {
+		logger = logger.WithField("Encap", kernelRoute.Encap)
+	}
+	return logger
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package linkstate allows storing the original state of the kernel interface in per Connection.Id metadata
+package linkstate
+
+import (
+	"context"
+	"net"
+
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+)
+
+type key struct{}
+
+// State is the original state of the kernel interface saved by the chain elements before they change it
+type State struct {
+	// MTU is the original MTU of the interface, 0 if not saved
+	MTU int
+	// HardwareAddr is the original MAC address of the interface, nil if not saved
+	HardwareAddr net.HardwareAddr
+	// Sysctls are the original values of the interface sysctls keyed by the /proc/sys file path
+	Sysctls map[string]string
+}
+
+// Store sets the State stored in per Connection.Id metadata.
+func Store(ctx context.Context, isClient bool, state *State) {
+	metadata.Map(ctx, isClient).Store(key{}, state)
+}
+
+// Delete deletes the State stored in per Connection.Id metadata
+func Delete(ctx context.Context, isClient bool) {
+	metadata.Map(ctx, isClient).Delete(key{})
+}
+
+// Load returns the State stored in per Connection.Id metadata, or nil if no
+// value is present.
+// The ok result indicates whether value was found in the per Connection.Id metadata.
+func Load(ctx context.Context, isClient bool) (state *State, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).Load(key{})
+	if !ok {
+		return
+	}
+	state, ok = rawValue.(*State)
+	return state, ok
+}
+
+// LoadOrStore returns the existing State stored in per Connection.Id metadata if present.
+// Otherwise, it stores and returns the given State.
+// The loaded result is true if the value was loaded, false if stored.
+func LoadOrStore(ctx context.Context, isClient bool, state *State) (value *State, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadOrStore(key{}, state)
+	if !ok {
+		return state, ok
+	}
+	value, ok = rawValue.(*State)
+	return value, ok
+}
+
+// LoadAndDelete deletes the State stored in per Connection.Id metadata,
+// returning the previous value if any. The loaded result reports whether the key was present.
+func LoadAndDelete(ctx context.Context, isClient bool) (state *State, ok bool) {
+	rawValue, ok := metadata.Map(ctx, isClient).LoadAndDelete(key{})
+	if !ok {
+		return
+	}
+	state, ok = rawValue.(*State)
+	return state, ok
+}
+
+// SaveMTU saves the original MTU of the interface unless it is already saved
+func SaveMTU(ctx context.Context, isClient bool, mtu int) {
+	state, _ := LoadOrStore(ctx, isClient, new(State))
+	if state.MTU == 0 {
+		state.MTU = mtu
+	}
+}
+
+// SaveHardwareAddr saves the original MAC address of the interface unless it is already saved
+func SaveHardwareAddr(ctx context.Context, isClient bool, hardwareAddr net.HardwareAddr) {
+	state, _ := LoadOrStore(ctx, isClient, new(State))
+	if state.HardwareAddr == nil {
+		state.HardwareAddr = append(net.HardwareAddr{}, hardwareAddr...)
+	}
+}
+
+// SaveSysctl saves the original value of the sysctl file unless it is already saved
+func SaveSysctl(ctx context.Context, isClient bool, path, value string) {
+	state, _ := LoadOrStore(ctx, isClient, new(State))
+	if state.Sysctls == nil {
+		state.Sysctls = make(map[string]string)
+	}
+	if _, ok := state.Sysctls[path]; !ok {
+		state.Sysctls[path] = value
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package nattemplate
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxChainNameLen is the iptables limit for the chain name length
+	maxChainNameLen = 28
+	chainHashLen    = 10
+)
+
+var builtinChainNames = map[string]bool{
+	"PREROUTING":  true,
+	"INPUT":       true,
+	"OUTPUT":      true,
+	"POSTROUTING": true,
+}
+
+// ChainPrefix returns the name prefix of the iptables chains owned by the connection
+func ChainPrefix(connID string) string {
+	return fmt.Sprintf("NSM-%x", sha256.Sum256([]byte(connID)))[:len("NSM-")+chainHashLen] + "-"
+}
+
+// ConnectionRules rewrites the rules so that they don't touch anything except the connection owned chains:
+//   - user defined chains are renamed to "<prefix><name>";
+//   - rules for the built-in chains are moved to the "<prefix><built-in chain>" chains;
+//   - the built-in chains get only the jumps to the connection chains, inserted at the top of the built-in chains
+//     with "-I <built-in chain> 1" after all the other rules;
+//   - policy rules are rejected, the policy of the built-in chains is shared by all the connections.
+func ConnectionRules(prefix string, rules []string) ([]string, error) {
+	var result, jumps []string
+	userChains := make(map[string]string)
+	for _, rule := range rules {
+		args := strings.Fields(rule)
+		if len(args) == 0 {
+			continue
+		}
+		if len(args) < 2 {
+			return nil, errors.Errorf("invalid rule: %s", rule)
+		}
+
+		chain := args[1]
+		switch {
+		case args[0] == "-P" || args[0] == "--policy":
+			return nil, errors.Errorf("policy rules are not supported: %s", rule)
+		case args[0] == "-N" || args[0] == "--new-chain":
+			name := prefix + chain
+			if len(name) > maxChainNameLen {
+				name = name[:maxChainNameLen]
+			}
+			for userChain, userChainName := range userChains {
+				if userChainName == name && userChain != chain {
+					return nil, errors.Errorf("chains %s and %s have the same name prefix", userChain, chain)
+				}
+			}
+			userChains[chain] = name
+			args[1] = name
+		case builtinChainNames[chain]:
+			name := prefix + chain
+			if !slices.Contains(result, "-N "+name) {
+				result = append(result, "-N "+name)
+				jumps = append(jumps, fmt.Sprintf("-I %s 1 -j %s", chain, name))
+			}
+			args[1] = name
+		case userChains[chain] != "":
+			args[1] = userChains[chain]
+		default:
+			return nil, errors.Errorf("chain %s is neither built-in nor created by the rules", chain)
+		}
+
+		for i := 2; i < len(args)-1; i++ {
+			if isJump(args[i]) && userChains[args[i+1]] != "" {
+				args[i+1] = userChains[args[i+1]]
+			}
+		}
+
+		result = append(result, strings.Join(args, " "))
+	}
+
+	return append(result, jumps...), nil
+}
+
+// jumpsTo returns true if the rule arguments contain a jump to the chain with the given name prefix
+func jumpsTo(args []string, prefix string) bool {
+	for i := 0; i < len(args)-1; i++ {
+		if isJump(args[i]) && strings.HasPrefix(args[i+1], prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isJump(arg string) bool {
+	return arg == "-j" || arg == "--jump" || arg == "-g" || arg == "--goto"
+}
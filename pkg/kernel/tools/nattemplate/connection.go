@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package nattemplate
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+	"github.com/networkservicemesh/sdk/pkg/networkservice/utils/metadata"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nshandle"
+)
+
+// Template is the nat rules template type of the kernel mechanism
+type Template struct {
+	// Name distinguishes the rules of the different template types applied for the same connection
+	Name string
+	// Evaluate returns the rules of the connection, it returns no rules if the mechanism has no template
+	Evaluate func(mechanism *kernel.Mechanism, conn *networkservice.Connection) ([]string, error)
+}
+
+type appliedRulesKey struct {
+	name string
+}
+
+// ApplyConnectionRules evaluates the template for the connection and applies the rules with manager if they are
+// changed since the previous Request
+func ApplyConnectionRules(ctx context.Context, conn *networkservice.Connection, manager Manager, isClient bool, template Template) error {
+	mechanism := kernel.ToMechanism(conn.GetMechanism())
+	if mechanism == nil {
+		return nil
+	}
+
+	rules, err := template.Evaluate(mechanism, conn)
+	if err != nil {
+		return err
+	}
+
+	ctxMap := metadata.Map(ctx, isClient)
+	appliedRules, rulesWasApplied := ctxMap.Load(appliedRulesKey{name: template.Name})
+
+	// Check refresh requests
+	if (rulesWasApplied && slices.Equal(appliedRules.([]string), rules)) || (!rulesWasApplied && len(rules) == 0) {
+		return nil
+	}
+
+	// Rules are stored before the apply to clean up partially applied ones on Close
+	ctxMap.Store(appliedRulesKey{name: template.Name}, rules)
+
+	return ApplyRules(manager, mechanism.GetNetNSURL(), conn.GetId(), rules)
+}
+
+// DeleteConnectionRules deletes the rules applied with ApplyConnectionRules for the connection
+func DeleteConnectionRules(ctx context.Context, conn *networkservice.Connection, manager Manager, isClient bool, template Template) error {
+	ctxMap := metadata.Map(ctx, isClient)
+	if _, rulesWasApplied := ctxMap.LoadAndDelete(appliedRulesKey{name: template.Name}); !rulesWasApplied {
+		return nil
+	}
+
+	return DeleteRules(manager, kernel.ToMechanism(conn.GetMechanism()).GetNetNSURL(), conn.GetId())
+}
+
+// ApplyRules replaces the connection chains in the netNSURL network namespace: the existing connection chains are
+// deleted and the new chains are created for rules. The chains are deleted even if the connection has no rules
+// applied, because they can be left in the network namespace by the previous forwarder, e.g. before the restart or
+// heal. rules can be empty.
+func ApplyRules(manager Manager, netNSURL, connID string, rules []string) error {
+	prefix := ChainPrefix(connID)
+	connRules, err := ConnectionRules(prefix, rules)
+	if err != nil {
+		return err
+	}
+
+	return runInNetNS(netNSURL, func() error {
+		if err := manager.Delete(prefix); err != nil {
+			return errors.Wrap(err, "failed to delete iptables rules")
+		}
+
+		if len(connRules) != 0 {
+			if err := manager.Apply(connRules); err != nil {
+				return errors.Wrap(err, "failed to apply iptables rules")
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteRules deletes the connection chains in the netNSURL network namespace
+func DeleteRules(manager Manager, netNSURL, connID string) error {
+	return runInNetNS(netNSURL, func() error {
+		return manager.Delete(ChainPrefix(connID))
+	})
+}
+
+func runInNetNS(netNSURL string, runner func() error) error {
+	currentNsHandler, err := nshandle.Current()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = currentNsHandler.Close() }()
+
+	targetHsHandler, err := nshandle.FromURL(netNSURL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = targetHsHandler.Close() }()
+
+	return nshandle.RunIn(currentNsHandler, targetHsHandler, runner)
+}
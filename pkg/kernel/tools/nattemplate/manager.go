@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package nattemplate provides managers applying iptables nat table rules into the per connection chains
+package nattemplate
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/edwarnicke/exechelper"
+	"github.com/pkg/errors"
+)
+
+// Manager provides methods for iptables nat rules management
+type Manager interface {
+	// Apply applies the iptables nat table commands
+	Apply(rules []string) error
+	// Delete deletes all the chains with the given name prefix and all the jumps to them
+	Delete(chainPrefix string) error
+}
+
+type iptablesManager struct {
+	cmdStr string
+}
+
+// NewIPTablesManager returns Manager running iptables binary for IPv4 rules
+func NewIPTablesManager() Manager {
+	return &iptablesManager{
+		cmdStr: "iptables -t nat",
+	}
+}
+
+// NewIP6TablesManager returns Manager running ip6tables binary for IPv6 rules
+func NewIP6TablesManager() Manager {
+	return &iptablesManager{
+		cmdStr: "ip6tables -t nat",
+	}
+}
+
+func (m *iptablesManager) Apply(rules []string) error {
+	for _, rule := range rules {
+		if _, err := m.run(strings.Split(rule, " ")...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *iptablesManager) Delete(chainPrefix string) error {
+	output, err := m.run("-S")
+	if err != nil {
+		return err
+	}
+
+	return m.Apply(DeleteCommands(output, chainPrefix))
+}
+
+// DeleteCommands returns the iptables commands deleting all the chains with the given name prefix and all the jumps
+// to them. The rules are the output of "iptables -S" or the table section of "iptables-save".
+func DeleteCommands(rules, chainPrefix string) []string {
+	var jumps, flushes, deletes []string
+	for _, line := range strings.Split(rules, "\n") {
+		args := strings.Fields(line)
+		if len(args) == 0 {
+			continue
+		}
+		// iptables-save declares the chains as ":<name> <policy> [<packets>:<bytes>]"
+		if strings.HasPrefix(args[0], ":") {
+			args = []string{"-N", strings.TrimPrefix(args[0], ":")}
+		}
+		if len(args) < 2 {
+			continue
+		}
+
+		switch {
+		case args[0] == "-N" && strings.HasPrefix(args[1], chainPrefix):
+			flushes = append(flushes, "-F "+args[1])
+			deletes = append(deletes, "-X "+args[1])
+		case args[0] == "-A" && !strings.HasPrefix(args[1], chainPrefix) && jumpsTo(args, chainPrefix):
+			jumps = append(jumps, strings.Join(append([]string{"-D"}, args[1:]...), " "))
+		}
+	}
+
+	return append(append(jumps, flushes...), deletes...)
+}
+
+func (m *iptablesManager) run(arguments ...string) (string, error) {
+	buf := bytes.NewBuffer([]byte{})
+	err := exechelper.Run(m.cmdStr,
+		exechelper.WithArgs(arguments...),
+		exechelper.WithStdout(buf),
+		exechelper.WithStderr(buf),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s", buf.String())
+	}
+
+	return buf.String(), nil
+}
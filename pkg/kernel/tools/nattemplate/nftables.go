@@ -0,0 +1,279 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package nattemplate
+
+import (
+	"strings"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/expr"
+	"github.com/google/nftables/userdata"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netns"
+)
+
+const natTableName = "nat"
+
+// builtinChains are the base chains of the iptables nat table, created the same way iptables-nft does
+var builtinChains = []*nftables.Chain{
+	{Name: "PREROUTING", Hooknum: nftables.ChainHookPrerouting, Priority: nftables.ChainPriorityNATDest},
+	{Name: "INPUT", Hooknum: nftables.ChainHookInput, Priority: nftables.ChainPriorityNATSource},
+	{Name: "OUTPUT", Hooknum: nftables.ChainHookOutput, Priority: nftables.ChainPriorityNATDest},
+	{Name: "POSTROUTING", Hooknum: nftables.ChainHookPostrouting, Priority: nftables.ChainPriorityNATSource},
+}
+
+type nftablesManager struct {
+	family nftables.TableFamily
+}
+
+// NewNFTablesManager returns Manager programming IPv4 rules into the "ip nat" nftables table over netlink.
+// It doesn't require any binaries in the image. Rules are expected in the iptables arguments format, only
+// -N, -A, -I, -D, -F, -X commands and -p, -s, -d, -i, -o, --sport, --dport matches are supported. Supported
+// targets are ACCEPT, DROP, RETURN, DNAT, SNAT, MASQUERADE, REDIRECT and the jump to a user defined chain, the other
+// targets are rejected.
+func NewNFTablesManager() Manager {
+	return &nftablesManager{
+		family: nftables.TableFamilyIPv4,
+	}
+}
+
+// NewNFTables6Manager is the same as NewNFTablesManager, but for IPv6 rules and the "ip6 nat" nftables table
+func NewNFTables6Manager() Manager {
+	return &nftablesManager{
+		family: nftables.TableFamilyIPv6,
+	}
+}
+
+func (m *nftablesManager) Apply(rules []string) error {
+	return m.run(func(conn *nftables.Conn) error {
+		table := conn.AddTable(&nftables.Table{Family: m.family, Name: natTableName})
+		for _, chain := range builtinChains {
+			conn.AddChain(&nftables.Chain{
+				Name:     chain.Name,
+				Table:    table,
+				Hooknum:  chain.Hooknum,
+				Priority: chain.Priority,
+				Type:     nftables.ChainTypeNAT,
+			})
+		}
+		if err := conn.Flush(); err != nil {
+			return errors.Wrapf(err, "failed to create nftables table %s", natTableName)
+		}
+
+		// The rules can jump only to the user defined chains
+		chains, err := m.chains(conn, table)
+		if err != nil {
+			return err
+		}
+		userChains := make(map[string]bool)
+		for _, chain := range chains {
+			userChains[chain.Name] = chain.Hooknum == nil
+		}
+
+		for _, rule := range rules {
+			if strings.TrimSpace(rule) == "" {
+				continue
+			}
+
+			cmd, err := parseCommand(rule, m.family, userChains)
+			if err != nil {
+				return err
+			}
+			if err := m.apply(conn, table, cmd); err != nil {
+				return errors.Wrapf(err, "failed to apply rule: %s", rule)
+			}
+			switch cmd.op {
+			case opNewChain:
+				userChains[cmd.chain] = true
+			case opDeleteChain:
+				delete(userChains, cmd.chain)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *nftablesManager) Delete(chainPrefix string) error {
+	return m.run(func(conn *nftables.Conn) error {
+		table, err := m.table(conn)
+		if err != nil || table == nil {
+			return err
+		}
+
+		chains, err := m.chains(conn, table)
+		if err != nil {
+			return err
+		}
+
+		var owned []*nftables.Chain
+		for _, chain := range chains {
+			if strings.HasPrefix(chain.Name, chainPrefix) {
+				owned = append(owned, chain)
+				continue
+			}
+
+			rules, err := conn.GetRules(table, chain)
+			if err != nil {
+				return errors.Wrapf(err, "failed to list rules of the nftables chain %s", chain.Name)
+			}
+			for _, rule := range rules {
+				if ruleJumpsTo(rule, chainPrefix) {
+					_ = conn.DelRule(rule)
+				}
+			}
+		}
+		if err := conn.Flush(); err != nil {
+			return errors.Wrap(err, "failed to delete jumps to the nftables chains")
+		}
+
+		// Chains can jump to each other, so all of them should be flushed before the deletion
+		for _, chain := range owned {
+			conn.FlushChain(chain)
+		}
+		if err := conn.Flush(); err != nil {
+			return errors.Wrap(err, "failed to flush nftables chains")
+		}
+		for _, chain := range owned {
+			conn.DelChain(chain)
+		}
+
+		return errors.Wrap(conn.Flush(), "failed to delete nftables chains")
+	})
+}
+
+func (m *nftablesManager) apply(conn *nftables.Conn, table *nftables.Table, cmd *command) error {
+	chain := &nftables.Chain{Name: cmd.chain, Table: table}
+	switch cmd.op {
+	case opNewChain:
+		conn.AddChain(chain)
+	case opFlushChain:
+		conn.FlushChain(chain)
+	case opDeleteChain:
+		conn.DelChain(chain)
+	case opAppend:
+		conn.AddRule(m.rule(chain, cmd))
+	case opInsert:
+		rule := m.rule(chain, cmd)
+		if cmd.position > 1 {
+			rules, err := conn.GetRules(table, chain)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			if cmd.position > len(rules) {
+				conn.AddRule(rule)
+				break
+			}
+			rule.Position = rules[cmd.position-1].Handle
+		}
+		conn.InsertRule(rule)
+	case opDelete:
+		rules, err := conn.GetRules(table, chain)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if rule := findRule(rules, cmd.spec); rule != nil {
+			if err := conn.DelRule(rule); err != nil {
+				return errors.WithStack(err)
+			}
+			break
+		}
+		return errors.Errorf("rule is not found in the chain %s", cmd.chain)
+	}
+
+	return errors.WithStack(conn.Flush())
+}
+
+func (m *nftablesManager) rule(chain *nftables.Chain, cmd *command) *nftables.Rule {
+	return &nftables.Rule{
+		Table: chain.Table,
+		Chain: chain,
+		Exprs: cmd.exprs,
+		// The rule is tagged with its iptables spec to be able to find it on -D
+		UserData: userdata.AppendString(nil, userdata.TypeComment, cmd.spec),
+	}
+}
+
+func findRule(rules []*nftables.Rule, spec string) *nftables.Rule {
+	for _, rule := range rules {
+		if comment, ok := userdata.GetString(rule.UserData, userdata.TypeComment); ok && comment == spec {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func ruleJumpsTo(rule *nftables.Rule, chainPrefix string) bool {
+	for _, e := range rule.Exprs {
+		if verdict, ok := e.(*expr.Verdict); ok && (verdict.Kind == expr.VerdictJump || verdict.Kind == expr.VerdictGoto) &&
+			strings.HasPrefix(verdict.Chain, chainPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *nftablesManager) table(conn *nftables.Conn) (*nftables.Table, error) {
+	tables, err := conn.ListTablesOfFamily(m.family)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list nftables tables")
+	}
+	for _, table := range tables {
+		if table.Name == natTableName {
+			return table, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *nftablesManager) chains(conn *nftables.Conn, table *nftables.Table) ([]*nftables.Chain, error) {
+	chains, err := conn.ListChainsOfTableFamily(m.family)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list nftables chains")
+	}
+
+	var result []*nftables.Chain
+	for _, chain := range chains {
+		if chain.Table.Name == table.Name {
+			result = append(result, chain)
+		}
+	}
+
+	return result, nil
+}
+
+// run calls f with the nftables connection opened in the network namespace of the calling thread
+func (m *nftablesManager) run(f func(conn *nftables.Conn) error) error {
+	handle, err := netns.Get()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current network namespace")
+	}
+	defer func() { _ = handle.Close() }()
+
+	conn, err := nftables.New(nftables.WithNetNSFd(int(handle)))
+	if err != nil {
+		return errors.Wrap(err, "failed to create nftables connection")
+	}
+
+	return f(conn)
+}
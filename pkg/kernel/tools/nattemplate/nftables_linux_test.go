@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build perm
+// +build perm
+
+package nattemplate_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/google/nftables"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netns"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/nattemplate"
+)
+
+func TestNFTablesManager_ApplyDeletePerm(t *testing.T) {
+	samples := []struct {
+		name    string
+		manager nattemplate.Manager
+		family  nftables.TableFamily
+		dnat    string
+		snat    string
+	}{
+		{
+			name:    "IPv4",
+			manager: nattemplate.NewNFTablesManager(),
+			family:  nftables.TableFamilyIPv4,
+			dnat:    "-p tcp -d 172.16.0.100/32 --dport 8080 -j DNAT --to-destination 127.0.0.1:80",
+			snat:    "-s 172.16.0.0/24 -o eth0 -j MASQUERADE",
+		},
+		{
+			name:    "IPv6",
+			manager: nattemplate.NewNFTables6Manager(),
+			family:  nftables.TableFamilyIPv6,
+			dnat:    "-p tcp -d fd00::100 --dport 8080 -j DNAT --to-destination [::1]:80",
+			snat:    "-s fd00::/64 -o eth0 -j SNAT --to-source fd01::1",
+		},
+	}
+
+	for _, sample := range samples {
+		t.Run(sample.name, func(t *testing.T) {
+			testApplyDelete(t, sample.manager, sample.family, sample.dnat, sample.snat)
+		})
+	}
+}
+
+func testApplyDelete(t *testing.T, manager nattemplate.Manager, family nftables.TableFamily, dnat, snat string) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	baseHandle, err := netns.Get()
+	require.NoError(t, err)
+	defer func() {
+		_ = netns.Set(baseHandle)
+		_ = baseHandle.Close()
+	}()
+
+	targetHandle, err := netns.New()
+	require.NoError(t, err)
+	defer func() { _ = targetHandle.Close() }()
+
+	for _, prefix := range []string{"NSM-1-", "NSM-2-"} {
+		require.NoError(t, manager.Apply([]string{
+			"-N " + prefix + "NSM_PREROUTE",
+			"-A " + prefix + "NSM_PREROUTE " + dnat,
+			"-A " + prefix + "NSM_PREROUTE -j ACCEPT",
+			"-N " + prefix + "PREROUTING",
+			"-I " + prefix + "PREROUTING 1 -p tcp -i nsm+ -j " + prefix + "NSM_PREROUTE",
+			"-N " + prefix + "POSTROUTING",
+			"-A " + prefix + "POSTROUTING " + snat,
+			"-D " + prefix + "NSM_PREROUTE -j ACCEPT",
+			"-I PREROUTING 1 -j " + prefix + "PREROUTING",
+			"-I POSTROUTING 1 -j " + prefix + "POSTROUTING",
+		}))
+	}
+
+	require.Error(t, manager.Apply([]string{"-A NSM-1-NSM_PREROUTE -m conntrack --ctstate NEW -j ACCEPT"}))
+	require.Error(t, manager.Apply([]string{"-A NSM-1-NSM_PREROUTE -j LOG"}))
+	require.Error(t, manager.Apply([]string{"-A NSM-1-NSM_PREROUTE -j PREROUTING"}))
+
+	require.NoError(t, manager.Delete("NSM-1-"))
+
+	conn, err := nftables.New(nftables.WithNetNSFd(int(targetHandle)))
+	require.NoError(t, err)
+
+	table := &nftables.Table{Family: family, Name: "nat"}
+	chains, err := conn.ListChainsOfTableFamily(family)
+	require.NoError(t, err)
+
+	rulesCount := make(map[string]int)
+	for _, chain := range chains {
+		rules, rulesErr := conn.GetRules(table, chain)
+		require.NoError(t, rulesErr)
+		rulesCount[chain.Name] = len(rules)
+	}
+	require.Equal(t, map[string]int{
+		"PREROUTING":         1,
+		"INPUT":              0,
+		"OUTPUT":             0,
+		"POSTROUTING":        1,
+		"NSM-2-NSM_PREROUTE": 1,
+		"NSM-2-PREROUTING":   1,
+		"NSM-2-POSTROUTING":  1,
+	}, rulesCount)
+}
@@ -0,0 +1,405 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package nattemplate
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	opNewChain = iota
+	opAppend
+	opInsert
+	opDelete
+	opFlushChain
+	opDeleteChain
+)
+
+var commands = map[string]int{
+	"-N": opNewChain, "--new-chain": opNewChain,
+	"-A": opAppend, "--append": opAppend,
+	"-I": opInsert, "--insert": opInsert,
+	"-D": opDelete, "--delete": opDelete,
+	"-F": opFlushChain, "--flush": opFlushChain,
+	"-X": opDeleteChain, "--delete-chain": opDeleteChain,
+}
+
+var protocols = map[string]byte{
+	"tcp":  unix.IPPROTO_TCP,
+	"udp":  unix.IPPROTO_UDP,
+	"sctp": unix.IPPROTO_SCTP,
+	"icmp": unix.IPPROTO_ICMP,
+
+	"icmpv6":    unix.IPPROTO_ICMPV6,
+	"ipv6-icmp": unix.IPPROTO_ICMPV6,
+}
+
+// ipFamily describes the network header of the nftables table family
+type ipFamily struct {
+	addrLen   int
+	srcOffset uint32
+	dstOffset uint32
+	nfProto   uint32
+}
+
+var ipFamilies = map[nftables.TableFamily]*ipFamily{
+	nftables.TableFamilyIPv4: {addrLen: net.IPv4len, srcOffset: 12, dstOffset: 16, nfProto: unix.NFPROTO_IPV4},
+	nftables.TableFamilyIPv6: {addrLen: net.IPv6len, srcOffset: 8, dstOffset: 24, nfProto: unix.NFPROTO_IPV6},
+}
+
+// ip returns the IP in the family representation or nil if the IP belongs to the other family
+func (f *ipFamily) ip(ip net.IP) net.IP {
+	if f.addrLen == net.IPv4len {
+		return ip.To4()
+	}
+	if ip.To4() != nil {
+		return nil
+	}
+	return ip.To16()
+}
+
+type command struct {
+	op       int
+	chain    string
+	position int
+	spec     string
+	exprs    []expr.Any
+}
+
+type ruleSpec struct {
+	family    *ipFamily
+	protocol  string
+	src, dst  string
+	in, out   string
+	sport     string
+	dport     string
+	target    string
+	toAddress string
+	toPorts   string
+	// userChains are the names of the chains the rule can jump to
+	userChains map[string]bool
+}
+
+var ruleFlags = map[string]func(s *ruleSpec) *string{
+	"-p":                 func(s *ruleSpec) *string { return &s.protocol },
+	"--protocol":         func(s *ruleSpec) *string { return &s.protocol },
+	"-s":                 func(s *ruleSpec) *string { return &s.src },
+	"--source":           func(s *ruleSpec) *string { return &s.src },
+	"-d":                 func(s *ruleSpec) *string { return &s.dst },
+	"--destination":      func(s *ruleSpec) *string { return &s.dst },
+	"-i":                 func(s *ruleSpec) *string { return &s.in },
+	"--in-interface":     func(s *ruleSpec) *string { return &s.in },
+	"-o":                 func(s *ruleSpec) *string { return &s.out },
+	"--out-interface":    func(s *ruleSpec) *string { return &s.out },
+	"--sport":            func(s *ruleSpec) *string { return &s.sport },
+	"--source-port":      func(s *ruleSpec) *string { return &s.sport },
+	"--dport":            func(s *ruleSpec) *string { return &s.dport },
+	"--destination-port": func(s *ruleSpec) *string { return &s.dport },
+	"-j":                 func(s *ruleSpec) *string { return &s.target },
+	"--jump":             func(s *ruleSpec) *string { return &s.target },
+	"--to-destination":   func(s *ruleSpec) *string { return &s.toAddress },
+	"--to-source":        func(s *ruleSpec) *string { return &s.toAddress },
+	"--to-ports":         func(s *ruleSpec) *string { return &s.toPorts },
+}
+
+// parseCommand translates the iptables nat table command into the nftables command, userChains are the names of the
+// user defined chains the rule can jump to
+func parseCommand(rule string, family nftables.TableFamily, userChains map[string]bool) (*command, error) {
+	args := strings.Fields(rule)
+	if len(args) < 2 {
+		return nil, errors.Errorf("invalid rule: %s", rule)
+	}
+
+	op, ok := commands[args[0]]
+	if !ok {
+		return nil, errors.Errorf("unsupported command %s in rule: %s", args[0], rule)
+	}
+	cmd := &command{
+		op:    op,
+		chain: args[1],
+	}
+	args = args[2:]
+
+	switch op {
+	case opNewChain, opFlushChain, opDeleteChain:
+		if len(args) != 0 {
+			return nil, errors.Errorf("unexpected arguments in rule: %s", rule)
+		}
+		return cmd, nil
+	case opInsert:
+		if len(args) != 0 {
+			if position, err := strconv.Atoi(args[0]); err == nil {
+				if position < 1 {
+					return nil, errors.Errorf("invalid rule position in rule: %s", rule)
+				}
+				cmd.position = position
+				args = args[1:]
+			}
+		}
+	}
+
+	spec, err := parseRuleSpec(args, ipFamilies[family])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid rule: %s", rule)
+	}
+	spec.userChains = userChains
+	if cmd.exprs, err = spec.exprs(); err != nil {
+		return nil, errors.Wrapf(err, "invalid rule: %s", rule)
+	}
+	cmd.spec = strings.Join(args, " ")
+
+	return cmd, nil
+}
+
+func parseRuleSpec(args []string, family *ipFamily) (*ruleSpec, error) {
+	spec := &ruleSpec{family: family}
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			return nil, errors.Errorf("no value for %s", args[i])
+		}
+
+		// Protocol matches are implicitly loaded by the -p option
+		if args[i] == "-m" || args[i] == "--match" {
+			if _, ok := protocols[args[i+1]]; !ok {
+				return nil, errors.Errorf("unsupported match %s", args[i+1])
+			}
+			continue
+		}
+
+		field, ok := ruleFlags[args[i]]
+		if !ok {
+			return nil, errors.Errorf("unsupported option %s", args[i])
+		}
+		*field(spec) = args[i+1]
+	}
+
+	return spec, nil
+}
+
+func (s *ruleSpec) exprs() ([]expr.Any, error) {
+	var exprs []expr.Any
+
+	if s.protocol != "" {
+		protocol, ok := protocols[s.protocol]
+		if !ok {
+			return nil, errors.Errorf("unsupported protocol %s", s.protocol)
+		}
+		exprs = append(exprs,
+			&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1},
+			&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{protocol}},
+		)
+	} else if s.sport != "" || s.dport != "" {
+		return nil, errors.New("port match requires the protocol")
+	}
+
+	for _, match := range []struct {
+		value  string
+		offset uint32
+	}{{s.src, s.family.srcOffset}, {s.dst, s.family.dstOffset}} {
+		if match.value == "" {
+			continue
+		}
+		addrExprs, err := addressExprs(match.value, match.offset, s.family)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, addrExprs...)
+	}
+
+	if s.in != "" {
+		exprs = append(exprs, interfaceExprs(expr.MetaKeyIIFNAME, s.in)...)
+	}
+	if s.out != "" {
+		exprs = append(exprs, interfaceExprs(expr.MetaKeyOIFNAME, s.out)...)
+	}
+
+	for _, match := range []struct {
+		value  string
+		offset uint32
+	}{{s.sport, 0}, {s.dport, 2}} {
+		if match.value == "" {
+			continue
+		}
+		portExprs, err := portExprs(match.value, match.offset)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, portExprs...)
+	}
+
+	targetExprs, err := s.targetExprs()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(exprs, &expr.Counter{}), targetExprs...), nil
+}
+
+func (s *ruleSpec) targetExprs() ([]expr.Any, error) {
+	switch s.target {
+	case "":
+		return nil, nil
+	case "ACCEPT":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictAccept}}, nil
+	case "DROP":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictDrop}}, nil
+	case "RETURN":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictReturn}}, nil
+	case "DNAT":
+		return natExprs(expr.NATTypeDestNAT, s.toAddress, s.family)
+	case "SNAT":
+		return natExprs(expr.NATTypeSourceNAT, s.toAddress, s.family)
+	case "MASQUERADE":
+		if s.toPorts == "" {
+			return []expr.Any{&expr.Masq{}}, nil
+		}
+		port, err := parsePort(s.toPorts)
+		if err != nil {
+			return nil, err
+		}
+		return []expr.Any{
+			&expr.Immediate{Register: 1, Data: port},
+			&expr.Masq{ToPorts: true, RegProtoMin: 1},
+		}, nil
+	case "REDIRECT":
+		if s.toPorts == "" {
+			return []expr.Any{&expr.Redir{}}, nil
+		}
+		port, err := parsePort(s.toPorts)
+		if err != nil {
+			return nil, err
+		}
+		return []expr.Any{
+			&expr.Immediate{Register: 1, Data: port},
+			&expr.Redir{RegisterProtoMin: 1},
+		}, nil
+	default:
+		if !s.userChains[s.target] {
+			return nil, errors.Errorf("unsupported target %s, it is neither built-in nor user defined chain", s.target)
+		}
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictJump, Chain: s.target}}, nil
+	}
+}
+
+func addressExprs(value string, offset uint32, family *ipFamily) ([]expr.Any, error) {
+	if !strings.Contains(value, "/") {
+		value += "/" + strconv.Itoa(8*family.addrLen)
+	}
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil || family.ip(ipNet.IP) == nil {
+		return nil, errors.Errorf("invalid address %s", value)
+	}
+
+	exprs := []expr.Any{
+		&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseNetworkHeader, Offset: offset, Len: uint32(family.addrLen)},
+	}
+	if ones, _ := ipNet.Mask.Size(); ones != 8*family.addrLen {
+		exprs = append(exprs, &expr.Bitwise{
+			SourceRegister: 1,
+			DestRegister:   1,
+			Len:            uint32(family.addrLen),
+			Mask:           ipNet.Mask,
+			Xor:            make([]byte, family.addrLen),
+		})
+	}
+
+	return append(exprs, &expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: family.ip(ipNet.IP)}), nil
+}
+
+func interfaceExprs(key expr.MetaKey, name string) []expr.Any {
+	// "eth+" matches any interface name starting with "eth", so only the prefix is compared
+	data := []byte(strings.TrimSuffix(name, "+"))
+	if !strings.HasSuffix(name, "+") {
+		data = append(data, make([]byte, unix.IFNAMSIZ-len(data))...)
+	}
+
+	return []expr.Any{
+		&expr.Meta{Key: key, Register: 1},
+		&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: data},
+	}
+}
+
+func portExprs(value string, offset uint32) ([]expr.Any, error) {
+	exprs := []expr.Any{
+		&expr.Payload{DestRegister: 1, Base: expr.PayloadBaseTransportHeader, Offset: offset, Len: 2},
+	}
+
+	if from, to, ok := strings.Cut(value, ":"); ok {
+		fromPort, err := parsePort(from)
+		if err != nil {
+			return nil, err
+		}
+		toPort, err := parsePort(to)
+		if err != nil {
+			return nil, err
+		}
+		return append(exprs, &expr.Range{Op: expr.CmpOpEq, Register: 1, FromData: fromPort, ToData: toPort}), nil
+	}
+
+	port, err := parsePort(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(exprs, &expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: port}), nil
+}
+
+func natExprs(natType expr.NATType, value string, family *ipFamily) ([]expr.Any, error) {
+	address, port := strings.Trim(value, "[]"), ""
+	if host, p, err := net.SplitHostPort(value); err == nil {
+		address, port = host, p
+	}
+
+	ip := family.ip(net.ParseIP(address))
+	if ip == nil {
+		return nil, errors.Errorf("invalid NAT address %s", value)
+	}
+
+	exprs := []expr.Any{&expr.Immediate{Register: 1, Data: ip}}
+	nat := &expr.NAT{Type: natType, Family: family.nfProto, RegAddrMin: 1}
+	if port != "" {
+		portData, err := parsePort(port)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, &expr.Immediate{Register: 2, Data: portData})
+		nat.RegProtoMin = 2
+		nat.Specified = true
+	}
+
+	return append(exprs, nat), nil
+}
+
+func parsePort(value string) ([]byte, error) {
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return nil, errors.Errorf("invalid port %s", value)
+	}
+
+	return binaryutil.BigEndian.PutUint16(uint16(port)), nil
+}
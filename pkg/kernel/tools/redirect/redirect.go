@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package redirect provides helpers redirecting all the packets received on one link to the egress of another link
+// with clsact qdisc and tc mirred action
+package redirect
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// priority is the tc filter priority used for the redirect filters
+const priority = 0xff
+
+// Add redirects all the packets received on the from link to the egress of the to link. It is idempotent, so it can
+// be called on each refresh. The from link supports exactly one redirect: all the redirect filters match all the
+// packets on the same priority, so only the first one fires. The stale redirects to the already deleted links, e.g.
+// to the recreated to link, are removed.
+func Add(ctx context.Context, handle *netlink.Handle, from, to netlink.Link) error {
+	filters, err := list(handle, from)
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	for _, filter := range filters {
+		mirred := getMirred(filter)
+		switch {
+		case mirred == nil:
+		case mirred.Ifindex == to.Attrs().Index:
+			found = true
+		case isStale(handle, mirred):
+			if err := filterDel(ctx, handle, from, filter); err != nil {
+				return err
+			}
+		}
+	}
+	if found {
+		return nil
+	}
+
+	qdisc := &netlink.Clsact{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: from.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+	}
+	now := time.Now()
+	if err := handle.QdiscReplace(qdisc); err != nil {
+		return errors.Wrapf(err, "failed to add clsact qdisc to %s", from.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", from.Attrs().Name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "QdiscReplace").Debug("completed")
+
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: from.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_INGRESS,
+			Priority:  priority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		// nil selector matches all the packets
+		Actions: []netlink.Action{netlink.NewMirredAction(to.Attrs().Index)},
+	}
+	now = time.Now()
+	if err := handle.FilterAdd(filter); err != nil {
+		return errors.Wrapf(err, "failed to add redirect filter from %s to %s", from.Attrs().Name, to.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", from.Attrs().Name).
+		WithField("to.Name", to.Attrs().Name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "FilterAdd").Debug("completed")
+
+	return nil
+}
+
+// Del removes the redirect filters from the from link to the link with toIndex and the stale ones to the already
+// deleted links. The redirects to the other links and the clsact qdisc are left in place, because they can be shared
+// with the other elements.
+func Del(ctx context.Context, handle *netlink.Handle, from netlink.Link, toIndex int) error {
+	filters, err := list(handle, from)
+	if err != nil {
+		return err
+	}
+	for _, filter := range filters {
+		if mirred := getMirred(filter); mirred == nil || mirred.Ifindex != toIndex && !isStale(handle, mirred) {
+			continue
+		}
+		if err := filterDel(ctx, handle, from, filter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isStale returns true if the redirect target link is already deleted, the kernel resets its index to 0
+func isStale(handle *netlink.Handle, mirred *netlink.MirredAction) bool {
+	if mirred.Ifindex == 0 {
+		return true
+	}
+	_, err := handle.LinkByIndex(mirred.Ifindex)
+	return errors.As(err, &netlink.LinkNotFoundError{})
+}
+
+func filterDel(ctx context.Context, handle *netlink.Handle, from netlink.Link, filter netlink.Filter) error {
+	now := time.Now()
+	if err := handle.FilterDel(filter); err != nil && !errors.Is(err, unix.ENOENT) {
+		return errors.Wrapf(err, "failed to delete redirect filter from %s", from.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", from.Attrs().Name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "FilterDel").Debug("completed")
+	return nil
+}
+
+// DelQdisc removes the clsact qdisc from the link if there are no filters left on it
+func DelQdisc(ctx context.Context, handle *netlink.Handle, link netlink.Link) error {
+	for _, parent := range []uint32{netlink.HANDLE_MIN_INGRESS, netlink.HANDLE_MIN_EGRESS} {
+		filters, err := listParent(handle, link, parent)
+		if err != nil {
+			return err
+		}
+		if len(filters) > 0 {
+			return nil
+		}
+	}
+
+	qdisc := &netlink.Clsact{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+	}
+	now := time.Now()
+	if err := handle.QdiscDel(qdisc); err != nil && !errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.ENOENT) {
+		return errors.Wrapf(err, "failed to delete clsact qdisc from %s", link.Attrs().Name)
+	}
+	log.FromContext(ctx).
+		WithField("link.Name", link.Attrs().Name).
+		WithField("duration", time.Since(now)).
+		WithField("netlink", "QdiscDel").Debug("completed")
+
+	return nil
+}
+
+func list(handle *netlink.Handle, from netlink.Link) ([]netlink.Filter, error) {
+	return listParent(handle, from, netlink.HANDLE_MIN_INGRESS)
+}
+
+func listParent(handle *netlink.Handle, from netlink.Link, parent uint32) ([]netlink.Filter, error) {
+	filters, err := handle.FilterList(from, parent)
+	// There are no filters if there is no clsact qdisc yet
+	if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOENT) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list filters of %s", from.Attrs().Name)
+	}
+	return filters, nil
+}
+
+// getMirred returns the redirect action of the filter added by Add or nil
+func getMirred(filter netlink.Filter) *netlink.MirredAction {
+	u32, ok := filter.(*netlink.U32)
+	if !ok || u32.Priority != priority {
+		return nil
+	}
+	for _, action := range u32.Actions {
+		if mirred, ok := action.(*netlink.MirredAction); ok && mirred.MirredAction == netlink.TCA_EGRESS_REDIR {
+			return mirred
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build perm
+// +build perm
+
+package redirect_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/redirect"
+)
+
+func TestAddDelPerm(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	baseHandle, err := netns.Get()
+	require.NoError(t, err)
+	defer func() {
+		_ = netns.Set(baseHandle)
+		_ = baseHandle.Close()
+	}()
+
+	targetHandle, err := netns.New()
+	require.NoError(t, err)
+	defer func() { _ = targetHandle.Close() }()
+
+	handle, err := netlink.NewHandle()
+	require.NoError(t, err)
+	defer handle.Close()
+
+	from, to, other := addVeth(t, handle, "from"), addVeth(t, handle, "to"), addVeth(t, handle, "other")
+
+	ctx := context.Background()
+	require.NoError(t, redirect.Add(ctx, handle, from, to))
+	require.NoError(t, redirect.Add(ctx, handle, from, to))
+	require.Equal(t, []int{to.Attrs().Index}, redirects(t, handle, from))
+
+	// The other element redirects from the same link
+	require.NoError(t, redirect.Add(ctx, handle, from, other))
+	require.ElementsMatch(t, []int{to.Attrs().Index, other.Attrs().Index}, redirects(t, handle, from))
+
+	require.NoError(t, redirect.Del(ctx, handle, from, to.Attrs().Index))
+	require.Equal(t, []int{other.Attrs().Index}, redirects(t, handle, from))
+
+	// The stale redirect to the deleted link is replaced
+	require.NoError(t, handle.LinkDel(other))
+	other = addVeth(t, handle, "other")
+	require.NoError(t, redirect.Add(ctx, handle, from, other))
+	require.Equal(t, []int{other.Attrs().Index}, redirects(t, handle, from))
+
+	require.NoError(t, redirect.Del(ctx, handle, from, other.Attrs().Index))
+	require.Empty(t, redirects(t, handle, from))
+	require.NoError(t, redirect.DelQdisc(ctx, handle, from))
+
+	qdiscs, err := handle.QdiscList(from)
+	require.NoError(t, err)
+	for _, qdisc := range qdiscs {
+		require.NotEqual(t, "clsact", qdisc.Type())
+	}
+}
+
+func addVeth(t *testing.T, handle *netlink.Handle, name string) netlink.Link {
+	require.NoError(t, handle.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: name},
+		PeerName:  name + "-peer",
+	}))
+	l, err := handle.LinkByName(name)
+	require.NoError(t, err)
+	require.NoError(t, handle.LinkSetUp(l))
+	return l
+}
+
+// redirects returns the indexes of the links the packets received on the link are redirected to
+func redirects(t *testing.T, handle *netlink.Handle, l netlink.Link) []int {
+	filters, err := handle.FilterList(l, netlink.HANDLE_MIN_INGRESS)
+	require.NoError(t, err)
+
+	var result []int
+	for _, filter := range filters {
+		u32, ok := filter.(*netlink.U32)
+		if !ok {
+			continue
+		}
+		for _, action := range u32.Actions {
+			if mirred, ok := action.(*netlink.MirredAction); ok {
+				result = append(result, mirred.Ifindex)
+			}
+		}
+	}
+	return result
+}
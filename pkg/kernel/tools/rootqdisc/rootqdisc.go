@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+// Package rootqdisc provides the helpers for the elements replacing the root qdisc of the connection interfaces, so
+// they don't silently replace the root qdisc of each other
+package rootqdisc
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+)
+
+// Get returns the root qdisc of the link or nil if the link has the default root qdisc set by the kernel
+func Get(handle *netlink.Handle, l netlink.Link) (netlink.Qdisc, error) {
+	qdiscs, err := handle.QdiscList(l)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list qdiscs of %s", l.Attrs().Name)
+	}
+	for _, qdisc := range qdiscs {
+		// The default root qdiscs have no handle
+		if qdisc.Attrs().Parent == netlink.HANDLE_ROOT && qdisc.Attrs().Handle != 0 {
+			return qdisc, nil
+		}
+	}
+	return nil, nil
+}
+
+// Check returns an error if the link has the root qdisc other than the default one or the caller one. The root qdisc
+// with qdiscHandle of one of the kinds is the caller one, it may be left by the caller before the restart. The caller
+// root qdisc is returned if the link has it.
+func Check(handle *netlink.Handle, l netlink.Link, qdiscHandle uint32, kinds ...string) (netlink.Qdisc, error) {
+	qdisc, err := Get(handle, l)
+	if err != nil || qdisc == nil {
+		return nil, err
+	}
+	if qdisc.Attrs().Handle == qdiscHandle && slices.Contains(kinds, qdisc.Type()) {
+		return qdisc, nil
+	}
+	return nil, errors.Errorf("link %s already has root qdisc %s %s", l.Attrs().Name, qdisc.Type(),
+		netlink.HandleStr(qdisc.Attrs().Handle))
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package routeattrs provides the attributes of the routes installed for the connections
+package routeattrs
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+)
+
+// Connection ExtraContext keys overriding the attributes set by the options
+const (
+	// MetricKey is the route metric (priority)
+	MetricKey = "routes.metric"
+	// MTUKey is the route MTU
+	MTUKey = "routes.mtu"
+	// AdvMSSKey is the route advertised MSS
+	AdvMSSKey = "routes.advmss"
+	// SrcKey is the comma separated list of the preferred source addresses, one per address family
+	SrcKey = "routes.src"
+)
+
+// Attributes are the attributes of the routes, zero values are not set
+type Attributes struct {
+	Metric   int
+	MTU      int
+	AdvMSS   int
+	Protocol netlink.RouteProtocol
+	// PreferredSource sets the preferred source of the routes to the connection IP address of the same family
+	PreferredSource bool
+
+	src []net.IP
+}
+
+// ForConnection returns the attributes for the connection overridden by the connection ExtraContext
+func (a *Attributes) ForConnection(conn *networkservice.Connection, isClient bool) (*Attributes, error) {
+	result := *a
+	result.src = nil
+
+	extraContext := conn.GetContext().GetExtraContext()
+	for key, value := range map[string]*int{
+		MetricKey: &result.Metric,
+		MTUKey:    &result.MTU,
+		AdvMSSKey: &result.AdvMSS,
+	} {
+		s, ok := extraContext[key]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, errors.Errorf("invalid %s: %s", key, s)
+		}
+		*value = n
+	}
+
+	if s, ok := extraContext[SrcKey]; ok {
+		for _, ipStr := range strings.Split(s, ",") {
+			ip := net.ParseIP(strings.TrimSpace(ipStr))
+			if ip == nil {
+				return nil, errors.Errorf("invalid %s: %s", SrcKey, s)
+			}
+			result.src = append(result.src, ip)
+		}
+		return &result, nil
+	}
+
+	if result.PreferredSource {
+		// The local addresses are the Dst ones on the client side and the Src ones on the server side, see ipaddress
+		ipNets := conn.GetContext().GetIpContext().GetSrcIPNets()
+		if isClient {
+			ipNets = conn.GetContext().GetIpContext().GetDstIPNets()
+		}
+		for _, ipNet := range ipNets {
+			result.src = append(result.src, ipNet.IP)
+		}
+	}
+
+	return &result, nil
+}
+
+// Apply sets the attributes to the route
+func (a *Attributes) Apply(route *netlink.Route) {
+	route.Priority = a.Metric
+	route.MTU = a.MTU
+	route.AdvMSS = a.AdvMSS
+	route.Protocol = a.Protocol
+	for _, ip := range a.src {
+		if (ip.To4() == nil) == (route.Dst.IP.To4() == nil) {
+			route.Src = ip
+			break
+		}
+	}
+}
+
+func (a *Attributes) String() string {
+	return fmt.Sprintf("metric %d mtu %d advmss %d proto %d src %v", a.Metric, a.MTU, a.AdvMSS, a.Protocol, a.src)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routeattrs_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/routeattrs"
+)
+
+func TestAttributes_ForConnection(t *testing.T) {
+	attrs := &routeattrs.Attributes{
+		Metric:          10,
+		MTU:             1400,
+		Protocol:        99,
+		PreferredSource: true,
+	}
+	conn := &networkservice.Connection{
+		Context: &networkservice.ConnectionContext{
+			IpContext: &networkservice.IPContext{
+				SrcIpAddrs: []string{"172.16.0.1/32", "fd00::1/128"},
+				DstIpAddrs: []string{"172.16.0.2/32"},
+			},
+			ExtraContext: map[string]string{
+				routeattrs.MetricKey: "20",
+			},
+		},
+	}
+
+	connAttrs, err := attrs.ForConnection(conn, false)
+	require.NoError(t, err)
+
+	route := &netlink.Route{Dst: &net.IPNet{IP: net.ParseIP("fd01::"), Mask: net.CIDRMask(64, 128)}}
+	connAttrs.Apply(route)
+	require.Equal(t, 20, route.Priority)
+	require.Equal(t, 1400, route.MTU)
+	require.Equal(t, netlink.RouteProtocol(99), route.Protocol)
+	require.Equal(t, net.ParseIP("fd00::1"), route.Src)
+
+	connAttrs, err = attrs.ForConnection(conn, true)
+	require.NoError(t, err)
+
+	route = &netlink.Route{Dst: &net.IPNet{IP: net.IPv4(10, 1, 0, 0), Mask: net.CIDRMask(16, 32)}}
+	connAttrs.Apply(route)
+	require.True(t, route.Src.Equal(net.IPv4(172, 16, 0, 2)))
+
+	conn.GetContext().GetExtraContext()[routeattrs.SrcKey] = "10.0.0.1"
+	connAttrs, err = attrs.ForConnection(conn, false)
+	require.NoError(t, err)
+
+	route = &netlink.Route{Dst: &net.IPNet{IP: net.IPv4(10, 1, 0, 0), Mask: net.CIDRMask(16, 32)}}
+	connAttrs.Apply(route)
+	require.True(t, route.Src.Equal(net.IPv4(10, 0, 0, 1)))
+
+	conn.GetContext().GetExtraContext()[routeattrs.MTUKey] = "-1"
+	_, err = attrs.ForConnection(conn, false)
+	require.Error(t, err)
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package tableid
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+type fileStore struct {
+	path string
+	mu   sync.Mutex
+	// restored are the tables read from the file on start, they may be left by the owners deleted before the restart
+	restored map[string]map[string]struct{}
+}
+
+// NewFileStore returns Store keeping the tables in the JSON file, so they survive the restarts. The tables restored
+// from the file are released on the allocation in the network namespace if they are not used there.
+func NewFileStore(path string) Store {
+	return &fileStore{
+		path: filepath.Clean(path),
+	}
+}
+
+func (s *fileStore) LoadOrStore(netNS string, tableID int, owner string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tables, err := s.read()
+	if err != nil {
+		return "", err
+	}
+
+	key := strconv.Itoa(tableID)
+	if owner, ok := tables[netNS][key]; ok {
+		return owner, nil
+	}
+	if tables[netNS] == nil {
+		tables[netNS] = make(map[string]string)
+	}
+	tables[netNS][key] = owner
+
+	return owner, s.write(tables)
+}
+
+func (s *fileStore) Delete(netNS string, tableID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tables, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	key := strconv.Itoa(tableID)
+	delete(s.restored[netNS], key)
+	if _, ok := tables[netNS][key]; !ok {
+		return nil
+	}
+	delete(tables[netNS], key)
+	if len(tables[netNS]) == 0 {
+		delete(tables, netNS)
+	}
+
+	return s.write(tables)
+}
+
+func (s *fileStore) Range(netNS string, f func(tableID int, owner string) bool) error {
+	s.mu.Lock()
+	tables, err := s.read()
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	for key, owner := range tables[netNS] {
+		tableID, err := strconv.Atoi(key)
+		if err != nil {
+			return errors.Wrapf(err, "invalid routing table ID %s in %s", key, s.path)
+		}
+		if !f(tableID, owner) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *fileStore) Prune(netNS string, used map[int]struct{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tables, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	var pruned bool
+	for key := range s.restored[netNS] {
+		// The restored tables are checked only once, the used ones are kept by their owners after the restart
+		delete(s.restored[netNS], key)
+		tableID, err := strconv.Atoi(key)
+		if err != nil {
+			return errors.Wrapf(err, "invalid routing table ID %s in %s", key, s.path)
+		}
+		if _, ok := used[tableID]; ok {
+			continue
+		}
+		if _, ok := tables[netNS][key]; ok {
+			delete(tables[netNS], key)
+			pruned = true
+		}
+	}
+	if !pruned {
+		return nil
+	}
+	if len(tables[netNS]) == 0 {
+		delete(tables, netNS)
+	}
+
+	return s.write(tables)
+}
+
+// read returns netNS -> table ID -> owner map
+func (s *fileStore) read() (map[string]map[string]string, error) {
+	tables := make(map[string]map[string]string)
+
+	data, err := os.ReadFile(s.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to read %s", s.path)
+	default:
+		if err := json.Unmarshal(data, &tables); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", s.path)
+		}
+	}
+
+	if s.restored == nil {
+		s.restored = make(map[string]map[string]struct{})
+		for netNS, nsTables := range tables {
+			s.restored[netNS] = make(map[string]struct{})
+			for key := range nsTables {
+				s.restored[netNS][key] = struct{}{}
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+func (s *fileStore) write(tables map[string]map[string]string) error {
+	data, err := json.Marshal(tables)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Write to the temporary file and rename it to not leave the corrupted file on crash
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+
+	return errors.Wrapf(os.Rename(tmpPath, s.path), "failed to write %s", s.path)
+}
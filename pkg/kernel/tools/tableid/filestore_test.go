@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package tableid_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/networkservicemesh/sdk-kernel/pkg/kernel/tools/tableid"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tables.json")
+
+	store := tableid.NewFileStore(path)
+	owner, err := store.LoadOrStore("ns1", 100, "conn-1")
+	require.NoError(t, err)
+	require.Equal(t, "conn-1", owner)
+
+	owner, err = store.LoadOrStore("ns1", 100, "conn-2")
+	require.NoError(t, err)
+	require.Equal(t, "conn-1", owner)
+
+	owner, err = store.LoadOrStore("ns2", 100, "conn-2")
+	require.NoError(t, err)
+	require.Equal(t, "conn-2", owner)
+
+	// The tables survive the restart
+	store = tableid.NewFileStore(path)
+	tables := make(map[int]string)
+	require.NoError(t, store.Range("ns1", func(tableID int, connID string) bool {
+		tables[tableID] = connID
+		return true
+	}))
+	require.Equal(t, map[int]string{100: "conn-1"}, tables)
+
+	require.NoError(t, store.Delete("ns1", 100))
+	owner, err = store.LoadOrStore("ns1", 100, "conn-2")
+	require.NoError(t, err)
+	require.Equal(t, "conn-2", owner)
+}
+
+type prunerStore interface {
+	tableid.Store
+	Prune(netNS string, used map[int]struct{}) error
+}
+
+func TestFileStorePrune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tables.json")
+
+	store := tableid.NewFileStore(path)
+	for tableID, connID := range map[int]string{100: "conn-1", 101: "conn-2"} {
+		_, err := store.LoadOrStore("ns1", tableID, connID)
+		require.NoError(t, err)
+	}
+
+	// The tables allocated by the running process are not pruned
+	require.NoError(t, store.(prunerStore).Prune("ns1", nil))
+
+	// The unused tables restored after the restart are pruned, the ones allocated after the restart are kept
+	store = tableid.NewFileStore(path)
+	_, err := store.LoadOrStore("ns1", 102, "conn-3")
+	require.NoError(t, err)
+	require.NoError(t, store.(prunerStore).Prune("ns1", map[int]struct{}{100: {}}))
+
+	tables := make(map[int]string)
+	require.NoError(t, tableid.NewFileStore(path).Range("ns1", func(tableID int, connID string) bool {
+		tables[tableID] = connID
+		return true
+	}))
+	require.Equal(t, map[int]string{100: "conn-1", 102: "conn-3"}, tables)
+}
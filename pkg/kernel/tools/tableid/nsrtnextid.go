@@ -1,6 +1,6 @@
 // Copyright (c) 2023 Nordix and/or its affiliates.
 //
-// Copyright (c) 2024 Cisco and/or its affiliates.
+// Copyright (c) 2024-2026 Cisco and/or its affiliates.
 //
 // SPDX-License-Identifier: Apache-2.0
 //
@@ -16,17 +16,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package iprule
+package tableid
 
 // netnsRTableNextID stores Network namespace and Next Routing Table ID
-// nolint: unused
 type netnsRTableNextID struct {
 	ns    string
 	nrtid int
 }
 
 // createNetnsRTableNextID returns netnsRTableNextID entry
-// nolint: unused
 func createNetnsRTableNextID(ns string, nrtid int) netnsRTableNextID {
 	return netnsRTableNextID{
 		ns:    ns,
@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+// Package tableid provides the allocation of the free routing tables in the network namespaces shared by the
+// elements installing the routes into the separate tables
+package tableid
+
+import (
+	"context"
+
+	"github.com/edwarnicke/genericsync"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/networkservicemesh/sdk/pkg/tools/log"
+)
+
+// Store stores the owners of the routing tables allocated in the network namespaces.
+// netNS is the unique network namespace ID, see nshandle.
+type Store interface {
+	// LoadOrStore returns the owner of the table, storing owner as the owner if the table is free
+	LoadOrStore(netNS string, tableID int, owner string) (string, error)
+	// Delete releases the table
+	Delete(netNS string, tableID int) error
+	// Range calls f for all the tables allocated in the network namespace until f returns false
+	Range(netNS string, f func(tableID int, owner string) bool) error
+}
+
+// pruner is implemented by Store keeping the tables across the restarts
+type pruner interface {
+	// Prune releases the tables allocated in the network namespace before the restart if they are not used
+	Prune(netNS string, used map[int]struct{}) error
+}
+
+type memoryStore struct {
+	tables genericsync.Map[netnsRTableNextID, string]
+}
+
+var defaultStore = NewMemoryStore()
+
+// NewMemoryStore returns Store keeping the tables in memory
+func NewMemoryStore() Store {
+	return new(memoryStore)
+}
+
+// DefaultStore returns the memory Store shared by all the elements of the process, so they never allocate the same
+// table in parallel
+func DefaultStore() Store {
+	return defaultStore
+}
+
+func (s *memoryStore) LoadOrStore(netNS string, tableID int, owner string) (string, error) {
+	actual, _ := s.tables.LoadOrStore(createNetnsRTableNextID(netNS, tableID), owner)
+	return actual, nil
+}
+
+func (s *memoryStore) Delete(netNS string, tableID int) error {
+	s.tables.Delete(createNetnsRTableNextID(netNS, tableID))
+	return nil
+}
+
+func (s *memoryStore) Range(netNS string, f func(tableID int, owner string) bool) error {
+	s.tables.Range(func(key netnsRTableNextID, owner string) bool {
+		if key.ns != netNS {
+			return true
+		}
+		return f(key.nrtid, owner)
+	})
+	return nil
+}
+
+// Allocator allocates free routing tables from the [min, max] range
+type Allocator struct {
+	min, max int
+	store    Store
+}
+
+// NewAllocator returns Allocator allocating the tables from the [minTableID, maxTableID] range and keeping them in
+// the store
+func NewAllocator(minTableID, maxTableID int, store Store) *Allocator {
+	return &Allocator{
+		min:   minTableID,
+		max:   maxTableID,
+		store: store,
+	}
+}
+
+// Allocate returns the first table in the network namespace that is neither used by the kernel nor allocated for the
+// other owner
+func (a *Allocator) Allocate(ctx context.Context, handle *netlink.Handle, netNS, owner string) (int, error) {
+	takenTableIDs, err := getTakenTableIDs(handle)
+	if err != nil {
+		return 0, err
+	}
+
+	// The owners of the tables allocated before the restart may be already deleted, e.g. together with the network
+	// namespace having the reused ID
+	if p, ok := a.store.(pruner); ok {
+		if err := p.Prune(netNS, takenTableIDs); err != nil {
+			return 0, errors.Wrap(err, "failed to prune routing table IDs")
+		}
+	}
+
+	for tableID := a.min; tableID <= a.max; tableID++ {
+		if _, ok := takenTableIDs[tableID]; ok {
+			continue
+		}
+
+		// Other owner can allocate the same free table in parallel
+		actual, err := a.store.LoadOrStore(netNS, tableID, owner)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to store routing table ID %d", tableID)
+		}
+		log.FromContext(ctx).
+			WithField("netNS", netNS).
+			WithField("tableID", tableID).
+			WithField("owner", actual).
+			Debug("tableid:allocate")
+		if actual == owner {
+			return tableID, nil
+		}
+	}
+
+	return 0, errors.Errorf("no free routing table ID in range [%d, %d]", a.min, a.max)
+}
+
+// Release releases the table in the network namespace
+func (a *Allocator) Release(netNS string, tableID int) error {
+	return errors.Wrapf(a.store.Delete(netNS, tableID), "failed to release routing table ID %d", tableID)
+}
+
+// Owned returns the tables allocated for the owner in the network namespace
+func (a *Allocator) Owned(netNS, owner string) (map[int]struct{}, error) {
+	tableIDs := make(map[int]struct{})
+	err := a.store.Range(netNS, func(tableID int, actual string) bool {
+		if actual == owner {
+			tableIDs[tableID] = struct{}{}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load routing table IDs")
+	}
+
+	return tableIDs, nil
+}
+
+func getTakenTableIDs(handle *netlink.Handle) (map[int]struct{}, error) {
+	routes, err := handle.RouteListFiltered(netlink.FAMILY_ALL,
+		&netlink.Route{
+			Table: unix.RT_TABLE_UNSPEC,
+		},
+		netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get free routing table ID, no routes")
+	}
+
+	rules, err := handle.RuleList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get free routing table ID, no rules")
+	}
+
+	links, err := handle.LinkList()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get free routing table ID, no links")
+	}
+
+	// tableID = 0 is reserved
+	takenTableIDs := map[int]struct{}{unix.RT_TABLE_UNSPEC: {}}
+	for i := 0; i < len(routes); i++ {
+		takenTableIDs[routes[i].Table] = struct{}{}
+	}
+
+	for i := 0; i < len(rules); i++ {
+		takenTableIDs[rules[i].Table] = struct{}{}
+	}
+
+	// An empty VRF table has no routes yet
+	for _, l := range links {
+		if vrf, ok := l.(*netlink.Vrf); ok {
+			takenTableIDs[int(vrf.Table)] = struct{}{}
+		}
+	}
+
+	return takenTableIDs, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package kernel
+
+import (
+	"strconv"
+
+	"github.com/networkservicemesh/api/pkg/api/networkservice/mechanisms/kernel"
+)
+
+// GetInterfaceName returns the name of the interface the connection context is applied to: the 802.1Q sub-interface
+// for the VLAN tagged connections or the mechanism interface otherwise
+func GetInterfaceName(mechanism *kernel.Mechanism) string {
+	if vlan := mechanism.GetVLAN(); vlan != 0 {
+		return GetVLANInterfaceName(mechanism.GetInterfaceName(), vlan)
+	}
+	return mechanism.GetInterfaceName()
+}
+
+// GetVLANInterfaceName returns "<ifName>.<vlan>" with ifName truncated to fit in the interface name length limit
+func GetVLANInterfaceName(ifName string, vlan uint32) string {
+	suffix := "." + strconv.FormatUint(uint64(vlan), 10)
+	if len(ifName)+len(suffix) > kernel.LinuxIfMaxLength {
+		ifName = ifName[:kernel.LinuxIfMaxLength-len(suffix)]
+	}
+	return ifName + suffix
+}